@@ -20,7 +20,17 @@ func main() {
 		os.Exit(1)
 	}
 
-	client.RegisterListener(EventListener)
+	// Any change to the windows of a workspace may change how many are tiled.
+	// Hyprland sends both versions of events like workspace and workspacev2, so
+	// only the V2 events are handled.
+	events.On(client, func(events.OpenWindowEvent) { CheckWorkspace() })
+	events.On(client, func(events.CloseWindowEvent) { CheckWorkspace() })
+	events.On(client, func(events.MoveWindowV2Event) { CheckWorkspace() })
+	events.On(client, func(events.ChangeFloatingModeEvent) { CheckWorkspace() })
+	events.On(client, func(events.WorkspaceV2Event) { CheckWorkspace() })
+	events.On(client, func(event events.ActiveSpecialV2Event) {
+		CheckSpecialWorkspace(event.WorkspaceName, event.MonitorName)
+	})
 
 	// Setup interrupt handler so we can cleanly close the event client
 	ctx, cancel := context.WithCancel(context.Background())
@@ -39,58 +49,27 @@ func main() {
 	}
 }
 
-func EventListener(event events.Event) {
-	switch event.(type) {
-	case events.OpenWindowEvent,
-		events.CloseWindowEvent,
-		events.MoveWindowEvent,
-		events.MoveWindowV2Event,
-		events.ChangeFloatingModeEvent,
-		events.WorkspaceEvent,
-		events.WorkspaceV2Event:
+func CheckSpecialWorkspace(workspaceName string, monitorName string) {
+	if workspaceName == "" {
+		// WorkspaceName is empty when we close a special workspace
 		CheckWorkspace()
-		break
-
-	case events.ActiveSpecialEvent:
-		event := event.(events.ActiveSpecialEvent)
-		if event.WorkspaceName == "" {
-			// WorkspaceName is empty when we close a special workspace
-			CheckWorkspace()
-		} else {
-			// Keep the special workspace full width, always
-			_ = RemoveReservedSpace(event.MonitorName)
-		}
-		break
-
-	case events.ActiveSpecialV2Event:
-		event := event.(events.ActiveSpecialV2Event)
-		if event.WorkspaceName == "" {
-			// WorkspaceName is empty when we close a special workspace
-			CheckWorkspace()
-		} else {
-			// Keep the special workspace full width, always
-			_ = RemoveReservedSpace(event.MonitorName)
-		}
-		break
+	} else {
+		// Keep the special workspace full width, always
+		_ = RemoveReservedSpace(monitorName)
 	}
 }
 
 func CheckWorkspace() {
-	workspace, err := hypr.GetActiveWorkspace()
-	if err != nil {
-		fmt.Printf("Error getting active workspace: %v\n", err)
-		return
-	}
-
-	windows, err := hypr.GetWindows()
+	snapshot, err := hypr.GetSnapshot()
 	if err != nil {
-		fmt.Printf("Error getting windows: %v\n", err)
+		fmt.Printf("Error getting snapshot: %v\n", err)
 		return
 	}
 
 	// Get the number of non-floating windows in this workspace
-	windows = slices.DeleteFunc(windows, func(window hypr.Window) bool {
-		return window.Workspace.Id != workspace.Id || window.Floating
+	workspace := snapshot.ActiveWorkspace
+	windows := slices.DeleteFunc(snapshot.WindowsOn(workspace.Id), func(window hypr.Window) bool {
+		return window.Floating
 	})
 
 	if len(windows) == 1 {
@@ -109,15 +88,17 @@ func CheckWorkspace() {
 }
 
 func AddReservedSpace(monitor string, top, bottom, left, right int) error {
-	return hypr.NewRequest().
+	_, err := hypr.NewRequest().
 		Notify(commands.IconInfo, 2000, commands.NotifyColorDefault, "Adding reserved space").
 		Keyword(fmt.Sprintf("monitor %s,addreserved,%d,%d,%d,%d", monitor, top, bottom, left, right)).
 		Send()
+	return err
 }
 
 func RemoveReservedSpace(monitor string) error {
-	return hypr.NewRequest().
+	_, err := hypr.NewRequest().
 		Notify(commands.IconInfo, 2000, commands.NotifyColorDefault, "Removing reserved space").
 		Keyword(fmt.Sprintf("monitor %s,addreserved,0,0,0,0", monitor)).
 		Send()
+	return err
 }
@@ -0,0 +1,102 @@
+package hypr
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Modifiers is the modifier mask of a keybind, as reported in the modmask of
+// GetBinds.
+type Modifiers uint32
+
+const (
+	ModShift Modifiers = 1 << iota
+	ModCaps
+	ModCtrl
+	ModAlt
+	ModMod2
+	ModMod3
+	ModSuper
+	ModMod5
+)
+
+// modifierNames lists the modifiers in the order they are rendered.
+var modifierNames = []struct {
+	modifier Modifiers
+	name     string
+}{
+	{ModSuper, "SUPER"},
+	{ModCtrl, "CTRL"},
+	{ModAlt, "ALT"},
+	{ModShift, "SHIFT"},
+	{ModCaps, "CAPS"},
+	{ModMod2, "MOD2"},
+	{ModMod3, "MOD3"},
+	{ModMod5, "MOD5"},
+}
+
+// ParseModifiers parses the modifiers of a bind in config syntax, such as
+// "SUPER SHIFT" or "CTRL_ALT". Like Hyprland, it looks for the name of every
+// modifier anywhere in the string, accepting CONTROL for CTRL, MOD1 for ALT and
+// WIN, LOGO, MOD4 or META for SUPER. Unknown names are ignored.
+func ParseModifiers(s string) Modifiers {
+	s = strings.ToUpper(s)
+
+	var mods Modifiers
+	for _, alias := range []struct {
+		modifier Modifiers
+		names    []string
+	}{
+		{ModShift, []string{"SHIFT"}},
+		{ModCaps, []string{"CAPS"}},
+		{ModCtrl, []string{"CTRL", "CONTROL"}},
+		{ModAlt, []string{"ALT", "MOD1"}},
+		{ModMod2, []string{"MOD2"}},
+		{ModMod3, []string{"MOD3"}},
+		{ModSuper, []string{"SUPER", "WIN", "LOGO", "MOD4", "META"}},
+		{ModMod5, []string{"MOD5"}},
+	} {
+		for _, name := range alias.names {
+			if strings.Contains(s, name) {
+				mods |= alias.modifier
+			}
+		}
+	}
+
+	return mods
+}
+
+// Has reports whether all modifiers of m are set.
+func (mods Modifiers) Has(m Modifiers) bool {
+	return mods&m == m
+}
+
+// Names returns the config names of the set modifiers, starting with SUPER, CTRL,
+// ALT and SHIFT.
+func (mods Modifiers) Names() []string {
+	names := make([]string, 0)
+	for _, modifier := range modifierNames {
+		if mods.Has(modifier.modifier) {
+			names = append(names, modifier.name)
+		}
+	}
+
+	return names
+}
+
+// String renders the modifiers in config syntax, such as "SUPER SHIFT". No
+// modifiers render as an empty string.
+func (mods Modifiers) String() string {
+	return strings.Join(mods.Names(), " ")
+}
+
+// Combo renders the key combination of the bind in config syntax, such as
+// "SUPER SHIFT, Q". Binds without a key name use "code:" and the keycode.
+func (b Bind) Combo() string {
+	key := b.Key
+	if key == "" && b.Keycode != 0 {
+		key = "code:" + strconv.Itoa(b.Keycode)
+	}
+
+	return b.Modifiers.String() + ", " + key
+}
@@ -0,0 +1,70 @@
+package hypr
+
+import "testing"
+
+func TestGetBinds(t *testing.T) {
+	reply := `[
+		{"locked":false,"mouse":false,"release":false,"repeat":false,"longPress":false,"non_consuming":false,"has_description":true,"modmask":65,"submap":"","key":"Q","keycode":0,"catch_all":false,"description":"Close window","dispatcher":"killactive","arg":""},
+		{"locked":true,"repeat":true,"modmask":0,"submap":"resize","key":"","keycode":121,"dispatcher":"exec","arg":"pamixer -t"}
+	]`
+
+	c, err := NewClient(WithSocketPath(serveOnce(t, reply)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	binds, err := c.GetBinds()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(binds) != 2 {
+		t.Fatalf("expected 2 binds, got %d", len(binds))
+	}
+
+	if binds[0].Modifiers != ModSuper|ModShift || binds[0].Description != "Close window" {
+		t.Errorf("unexpected bind %+v", binds[0])
+	}
+
+	if combo := binds[0].Combo(); combo != "SUPER SHIFT, Q" {
+		t.Errorf("expected %q, got %q", "SUPER SHIFT, Q", combo)
+	}
+
+	if combo := binds[1].Combo(); combo != ", code:121" || !binds[1].Locked || binds[1].Submap != "resize" {
+		t.Errorf("unexpected bind %+v rendered as %q", binds[1], combo)
+	}
+}
+
+func TestModifiers(t *testing.T) {
+	tests := []struct {
+		config   string
+		mods     Modifiers
+		rendered string
+	}{
+		{"", 0, ""},
+		{"SUPER", ModSuper, "SUPER"},
+		{"shift super", ModSuper | ModShift, "SUPER SHIFT"},
+		{"CONTROL_ALT", ModCtrl | ModAlt, "CTRL ALT"},
+		{"MOD4 MOD1", ModSuper | ModAlt, "SUPER ALT"},
+		{"CAPS MOD2 MOD3 MOD5", ModCaps | ModMod2 | ModMod3 | ModMod5, "CAPS MOD2 MOD3 MOD5"},
+	}
+
+	for _, test := range tests {
+		mods := ParseModifiers(test.config)
+		if mods != test.mods {
+			t.Errorf("ParseModifiers(%q): expected %d, got %d", test.config, test.mods, mods)
+		}
+
+		if mods.String() != test.rendered {
+			t.Errorf("%d: expected %q, got %q", mods, test.rendered, mods.String())
+		}
+
+		if ParseModifiers(mods.String()) != mods {
+			t.Errorf("%q does not parse back to %d", mods.String(), mods)
+		}
+	}
+
+	if !(ModSuper | ModShift).Has(ModShift) || ModSuper.Has(ModSuper|ModShift) {
+		t.Error("unexpected result from Has")
+	}
+}
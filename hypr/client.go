@@ -1,47 +1,219 @@
 package hypr
 
 import (
-	"bytes"
-	"errors"
+	"context"
 	"fmt"
+	"io"
 	"net"
-	"os"
+	"time"
+
+	"github.com/jstncnnr/go-hyprland/hypr/instance"
 )
 
-type client struct {
-	connection net.Conn
+// DefaultMaxResponseSize is the largest reply a Client reads unless configured
+// otherwise with WithMaxResponseSize.
+const DefaultMaxResponseSize = 32 << 20
+
+// Dialer opens the connection to the Hyprland request socket. *net.Dialer
+// satisfies this interface.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
 }
 
-func newClient() (*client, error) {
-	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
-	if runtimeDir == "" {
-		runtimeDir = "/tmp"
+// Client issues requests to the Hyprland request socket (.socket.sock). Hyprland
+// closes the socket after every reply, so a Client dials a new connection for
+// each request and is safe to reuse and share between goroutines.
+type Client struct {
+	instanceSignature string
+	runtimeDir        string
+	socketPath        string
+	dialer            Dialer
+	dialTimeout       time.Duration
+	timeout           time.Duration
+	maxResponseSize   int64
+}
+
+// Option configures a Client created with NewClient.
+type Option func(*Client)
+
+// WithInstance talks to the given instance, usually one found with the instance
+// package.
+func WithInstance(inst instance.Instance) Option {
+	return func(c *Client) {
+		c.instanceSignature = inst.Signature
+		c.runtimeDir = inst.RuntimeDir
 	}
+}
 
-	hyprlandInstance := os.Getenv("HYPRLAND_INSTANCE_SIGNATURE")
-	if hyprlandInstance == "" {
-		return nil, errors.New("HYPRLAND_INSTANCE_SIGNATURE environment variable not set. Please ensure Hyprland is running")
+// WithInstanceSignature selects the Hyprland instance to talk to instead of reading
+// the HYPRLAND_INSTANCE_SIGNATURE environment variable.
+func WithInstanceSignature(signature string) Option {
+	return func(c *Client) {
+		c.instanceSignature = signature
 	}
+}
 
-	socketPath := fmt.Sprintf("%s/hypr/%s/.socket.sock", runtimeDir, hyprlandInstance)
-	conn, err := net.Dial("unix", socketPath)
-	if err != nil {
-		return nil, errors.New(fmt.Sprintf("Unable to open hyprland socket: %v", err))
+// WithRuntimeDir overrides the runtime directory the instance sockets are found in.
+// Defaults to XDG_RUNTIME_DIR, or /tmp when that is not set.
+func WithRuntimeDir(dir string) Option {
+	return func(c *Client) {
+		c.runtimeDir = dir
+	}
+}
+
+// WithSocketPath uses the given socket path as is. The instance signature and
+// runtime directory are ignored when this is set.
+func WithSocketPath(path string) Option {
+	return func(c *Client) {
+		c.socketPath = path
+	}
+}
+
+// WithDialer sets the Dialer used to open connections to the socket.
+func WithDialer(dialer Dialer) Option {
+	return func(c *Client) {
+		c.dialer = dialer
+	}
+}
+
+// WithDialTimeout limits how long opening a connection to the socket may take.
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.dialTimeout = timeout
+	}
+}
+
+// WithTimeout limits how long a single request, from writing the command to
+// reading the full reply, may take.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
 	}
+}
 
-	return &client{
-		connection: conn,
-	}, nil
+// WithMaxResponseSize limits how many bytes of a reply are read before the request
+// fails with ErrResponseTooLarge. Defaults to DefaultMaxResponseSize.
+func WithMaxResponseSize(size int64) Option {
+	return func(c *Client) {
+		c.maxResponseSize = size
+	}
 }
 
-func (c *client) Close() error {
-	return c.connection.Close()
+// NewClient creates a Client for the request socket. Without options the instance
+// is found with instance.Current: HYPRLAND_INSTANCE_SIGNATURE when it is set,
+// otherwise the running instance for WAYLAND_DISPLAY or the only running instance.
+func NewClient(opts ...Option) (*Client, error) {
+	c := &Client{
+		dialer:          &net.Dialer{},
+		maxResponseSize: DefaultMaxResponseSize,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.socketPath == "" {
+		if c.runtimeDir == "" {
+			c.runtimeDir = instance.RuntimeDir()
+		}
+
+		if c.instanceSignature == "" {
+			current, err := instance.CurrentIn(c.runtimeDir)
+			if err != nil {
+				return nil, err
+			}
+
+			c.instanceSignature = current.Signature
+		}
+
+		c.socketPath = instance.Instance{
+			Signature:  c.instanceSignature,
+			RuntimeDir: c.runtimeDir,
+		}.SocketPath()
+	}
+
+	return c, nil
+}
+
+// SocketPath returns the path of the request socket used by this client.
+func (c *Client) SocketPath() string {
+	return c.socketPath
+}
+
+func (c *Client) dial(ctx context.Context) (net.Conn, error) {
+	if c.dialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.dialTimeout)
+		defer cancel()
+	}
+
+	conn, err := c.dialer.DialContext(ctx, "unix", c.socketPath)
+	if err != nil {
+		return nil, &SocketError{Path: c.socketPath, Err: err}
+	}
+
+	return conn, nil
 }
 
 // SendRequest sends a low level request directly to the socket. This should only
 // be used when no other option is available.
-func (c *client) SendRequest(command string) ([]byte, error) {
-	written, err := c.connection.Write([]byte(command))
+func (c *Client) SendRequest(command string) ([]byte, error) {
+	return c.SendRequestContext(context.Background(), command)
+}
+
+// SendRequestContext is like SendRequest but honors the deadline and cancellation
+// of ctx. A cancelled context aborts a request that is waiting on Hyprland.
+func (c *Client) SendRequestContext(ctx context.Context, command string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		return nil, err
+	}
+
+	defer func(conn net.Conn) {
+		_ = conn.Close()
+	}(conn)
+
+	var deadline time.Time
+	if c.timeout > 0 {
+		deadline = time.Now().Add(c.timeout)
+	}
+
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+
+	_ = conn.SetDeadline(deadline)
+
+	// Move the deadline into the past on cancellation so a blocked read or write
+	// returns immediately.
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Unix(1, 0))
+	})
+	defer stop()
+
+	resp, err := c.exchange(conn, command)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// The socket deadline can expire just before ctx notices its own deadline.
+	if ctxDeadline, ok := ctx.Deadline(); err != nil && ok && !time.Now().Before(ctxDeadline) {
+		return nil, context.DeadlineExceeded
+	}
+
+	return resp, err
+}
+
+func (c *Client) exchange(conn net.Conn, command string) ([]byte, error) {
+	written, err := conn.Write([]byte(command))
 	if err != nil {
 		return nil, err
 	}
@@ -50,27 +222,32 @@ func (c *client) SendRequest(command string) ([]byte, error) {
 		return nil, fmt.Errorf("expected to write %d bytes, wrote %d", len(command), written)
 	}
 
-	response := bytes.Buffer{}
-
-	buffer := make([]byte, 4096)
-	for {
-		read, err := c.connection.Read(buffer)
-		if err != nil {
-			return nil, err
-		}
+	// Hyprland closes the connection once the full reply is written, so read until
+	// EOF. One extra byte is allowed through the limit to detect oversized replies.
+	response, err := io.ReadAll(io.LimitReader(conn, c.maxResponseSize+1))
+	if err != nil && len(response) > 0 {
+		return nil, fmt.Errorf("%w after %d bytes: %w", ErrResponseTruncated, len(response), err)
+	}
 
-		response.Write(buffer[:read])
+	if err != nil {
+		return nil, err
+	}
 
-		if read != 4096 {
-			break
-		}
+	if int64(len(response)) > c.maxResponseSize {
+		return nil, fmt.Errorf("%w of %d bytes", ErrResponseTooLarge, c.maxResponseSize)
 	}
 
-	return response.Bytes(), nil
+	return response, nil
 }
 
 // SendJSONRequest sends a low level request directly to the socket and requests a
 // JSON response. This should only be used when no other option is available.
-func (c *client) SendJSONRequest(command string) ([]byte, error) {
-	return c.SendRequest("j/" + command)
+func (c *Client) SendJSONRequest(command string) ([]byte, error) {
+	return c.SendJSONRequestContext(context.Background(), command)
+}
+
+// SendJSONRequestContext is like SendJSONRequest but honors the deadline and
+// cancellation of ctx.
+func (c *Client) SendJSONRequestContext(ctx context.Context, command string) ([]byte, error) {
+	return c.SendRequestContext(ctx, "j/"+command)
 }
@@ -0,0 +1,279 @@
+package hypr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serveOnce answers a single request on a temporary socket by writing response in
+// small chunks, forcing the client to see short reads mid-stream.
+func serveOnce(t *testing.T, response string) string {
+	dir, err := os.MkdirTemp("", "hypr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socketPath := filepath.Join(dir, ".socket.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		buffer := make([]byte, 1024)
+		_, _ = conn.Read(buffer)
+
+		for len(response) > 0 {
+			chunk := min(len(response), 1000)
+			_, _ = conn.Write([]byte(response[:chunk]))
+			response = response[chunk:]
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	return socketPath
+}
+
+func TestSendRequestReadsUntilClose(t *testing.T) {
+	windows := make([]string, 0)
+	for i := 0; i < 200; i++ {
+		windows = append(windows, fmt.Sprintf(`{"address":"0x%x","title":"%s"}`, i, strings.Repeat("x", 200)))
+	}
+
+	c, err := NewClient(WithSocketPath(serveOnce(t, "["+strings.Join(windows, ",")+"]")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := c.GetWindows()
+	if err != nil {
+		t.Fatalf("GetWindows: %v", err)
+	}
+
+	if len(result) != len(windows) {
+		t.Errorf("expected %d windows, got %d", len(windows), len(result))
+	}
+}
+
+func TestSendRequestResponseTooLarge(t *testing.T) {
+	c, err := NewClient(
+		WithSocketPath(serveOnce(t, strings.Repeat("x", 4096))),
+		WithMaxResponseSize(1024),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.SendRequest("version"); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge, got %v", err)
+	}
+}
+
+func TestSendRequestNotRunning(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), ".socket.sock")
+	c, err := NewClient(WithSocketPath(socketPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.SendRequest("version")
+
+	var socketErr *SocketError
+	if !errors.As(err, &socketErr) || socketErr.Path != socketPath {
+		t.Errorf("expected a SocketError for %s, got %v", socketPath, err)
+	}
+
+	if !errors.Is(err, ErrNoInstance) {
+		t.Errorf("expected %v to match ErrNoInstance", err)
+	}
+}
+
+func TestQueryDecodeError(t *testing.T) {
+	c, err := NewClient(WithSocketPath(serveOnce(t, "unknown request")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.GetMonitors()
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.Command != "monitors" || string(decodeErr.Raw) != "unknown request" {
+		t.Errorf("expected a DecodeError with the raw reply, got %v", err)
+	}
+
+	var commandErr *CommandError
+	if !errors.As(err, &commandErr) || commandErr.Response != "unknown request" {
+		t.Errorf("expected a CommandError with the reply, got %v", err)
+	}
+}
+
+func TestNewClientSocketPath(t *testing.T) {
+	c, err := NewClient(
+		WithRuntimeDir("/run/user/1000"),
+		WithInstanceSignature("abc"),
+		WithSocketPath("/tmp/custom.sock"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.SocketPath() != "/tmp/custom.sock" {
+		t.Errorf("expected the socket path to be used as is, got %s", c.SocketPath())
+	}
+}
+
+func TestNewClientInstanceSignature(t *testing.T) {
+	t.Setenv("HYPRLAND_INSTANCE_SIGNATURE", "env")
+
+	c, err := NewClient(WithRuntimeDir("/run/user/1000"), WithInstanceSignature("abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "/run/user/1000/hypr/abc/.socket.sock"; c.SocketPath() != expected {
+		t.Errorf("expected %s, got %s", expected, c.SocketPath())
+	}
+}
+
+func TestNewClientEnvironment(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	t.Setenv("HYPRLAND_INSTANCE_SIGNATURE", "env")
+
+	c, err := NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "/run/user/1000/hypr/env/.socket.sock"; c.SocketPath() != expected {
+		t.Errorf("expected %s, got %s", expected, c.SocketPath())
+	}
+}
+
+type recordingDialer struct {
+	addresses []string
+}
+
+func (d *recordingDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.addresses = append(d.addresses, address)
+	return (&net.Dialer{}).DialContext(ctx, network, address)
+}
+
+func TestNewClientWithDialer(t *testing.T) {
+	socketPath := serveOnce(t, "ok")
+	dialer := &recordingDialer{}
+
+	c, err := NewClient(WithSocketPath(socketPath), WithDialer(dialer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.SendRequest("reload"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dialer.addresses) != 1 || dialer.addresses[0] != socketPath {
+		t.Errorf("expected the dialer to open %s once, got %v", socketPath, dialer.addresses)
+	}
+}
+
+// serveSilently accepts connections on a temporary socket but never replies.
+func serveSilently(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "hypr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socketPath := filepath.Join(dir, ".socket.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { _ = conn.Close() })
+		}
+	}()
+
+	return socketPath
+}
+
+func TestSendRequestContextCancel(t *testing.T) {
+	c, err := NewClient(WithSocketPath(serveSilently(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	if _, err := c.SendRequestContext(ctx, "version"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the request to be aborted promptly, took %v", elapsed)
+	}
+}
+
+func TestQueryContextDeadline(t *testing.T) {
+	c, err := NewClient(WithSocketPath(serveSilently(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := c.GetMonitorsContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the query to be aborted promptly, took %v", elapsed)
+	}
+}
+
+func TestSendRequestTimeout(t *testing.T) {
+	c, err := NewClient(WithSocketPath(serveSilently(t)), WithTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.SendRequest("version")
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("expected os.ErrDeadlineExceeded, got %v", err)
+	}
+
+	if errors.Is(err, ErrResponseTruncated) {
+		t.Errorf("expected no ErrResponseTruncated before any reply, got %v", err)
+	}
+
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("expected a timeout net.Error, got %v", err)
+	}
+}
@@ -9,6 +9,12 @@ type Command interface {
 	String() string
 }
 
+// Validator is implemented by commands that can check their arguments before they
+// are sent. hypr.Request refuses to send a command that fails validation.
+type Validator interface {
+	Validate() error
+}
+
 // DispatchCommand issues a dispatch to call a keybind dispatcher with an argument.
 // See https://wiki.hyprland.org/Configuring/Dispatchers for a list of dispatchers.
 type DispatchCommand struct {
@@ -185,3 +191,24 @@ func (cmd DismissNotifyCommand) String() string {
 
 	return fmt.Sprintf("dismissnotify %d", count)
 }
+
+// EventCommand emits a custom event with Data on the event socket, received as
+// `custom>>Data`.
+//
+// Data may not contain newlines, which would split the event, or semicolons, which
+// would split a batched request.
+type EventCommand struct {
+	Data string
+}
+
+func (cmd EventCommand) String() string {
+	return fmt.Sprintf("dispatch event %s", cmd.Data)
+}
+
+func (cmd EventCommand) Validate() error {
+	if strings.ContainsAny(cmd.Data, "\r\n;") {
+		return fmt.Errorf("event data may not contain newlines or semicolons: %q", cmd.Data)
+	}
+
+	return nil
+}
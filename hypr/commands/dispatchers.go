@@ -0,0 +1,792 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// dispatch formats a dispatcher call the same way Request.Dispatch does, passing a
+// placeholder argument to dispatchers that take none.
+func dispatch(dispatcher string, args ...string) string {
+	args = nonEmpty(args)
+	if len(args) == 0 {
+		args = []string{"unused"}
+	}
+
+	return DispatchCommand{Dispatcher: dispatcher, Args: args}.String()
+}
+
+// joinArgs joins the comma separated arguments of a dispatcher, leaving out
+// optional arguments that are not set.
+func joinArgs(args ...string) string {
+	return strings.Join(nonEmpty(args), ",")
+}
+
+func nonEmpty(args []string) []string {
+	result := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg != "" {
+			result = append(result, arg)
+		}
+	}
+
+	return result
+}
+
+// Direction is used by dispatchers that move focus or windows in a direction.
+type Direction string
+
+const (
+	DirectionLeft  Direction = "l"
+	DirectionRight Direction = "r"
+	DirectionUp    Direction = "u"
+	DirectionDown  Direction = "d"
+)
+
+// Vector is the argument of the resize and move dispatchers. A relative vector is
+// added to the current size or position while an exact one replaces it. Percent
+// values are relative to the size of the monitor.
+type Vector struct {
+	X       int
+	Y       int
+	Exact   bool
+	Percent bool
+}
+
+// Relative returns a vector that is added to the current size or position.
+func Relative(x int, y int) Vector {
+	return Vector{X: x, Y: y}
+}
+
+// Exact returns a vector that replaces the current size or position.
+func Exact(x int, y int) Vector {
+	return Vector{X: x, Y: y, Exact: true}
+}
+
+func (v Vector) String() string {
+	unit := ""
+	if v.Percent {
+		unit = "%"
+	}
+
+	value := fmt.Sprintf("%d%s %d%s", v.X, unit, v.Y, unit)
+	if v.Exact {
+		return "exact " + value
+	}
+
+	return value
+}
+
+// FullscreenMode is the mode used by FullscreenCommand.
+type FullscreenMode int
+
+const (
+	// FullscreenModeFullscreen takes up the whole monitor.
+	FullscreenModeFullscreen FullscreenMode = 0
+
+	// FullscreenModeMaximize keeps gaps and reserved space, such as bars.
+	FullscreenModeMaximize FullscreenMode = 1
+)
+
+// FullscreenState is the state used by FullscreenStateCommand.
+type FullscreenState int
+
+const (
+	FullscreenStateCurrent               FullscreenState = -1
+	FullscreenStateNone                  FullscreenState = 0
+	FullscreenStateMaximize              FullscreenState = 1
+	FullscreenStateFullscreen            FullscreenState = 2
+	FullscreenStateMaximizeAndFullscreen FullscreenState = 3
+)
+
+// LockAction is used by dispatchers that lock or unlock groups.
+type LockAction string
+
+const (
+	Lock       LockAction = "lock"
+	Unlock     LockAction = "unlock"
+	LockToggle LockAction = "toggle"
+)
+
+// DpmsAction is used by DpmsCommand.
+type DpmsAction string
+
+const (
+	DpmsOn     DpmsAction = "on"
+	DpmsOff    DpmsAction = "off"
+	DpmsToggle DpmsAction = "toggle"
+)
+
+// ExecCommand executes a shell command. Rules are optional window rules applied to
+// the first window it opens, e.g. "[workspace 2 silent; float]".
+type ExecCommand struct {
+	Rules   string
+	Command string
+}
+
+func (cmd ExecCommand) String() string {
+	if cmd.Rules == "" {
+		return dispatch("exec", cmd.Command)
+	}
+
+	return dispatch("exec", cmd.Rules, cmd.Command)
+}
+
+func (cmd ExecCommand) Validate() error {
+	if cmd.Command == "" {
+		return errors.New("command is required")
+	}
+
+	return nil
+}
+
+// ExecrCommand executes a raw shell command without support for window rules.
+type ExecrCommand struct {
+	Command string
+}
+
+func (cmd ExecrCommand) String() string {
+	return dispatch("execr", cmd.Command)
+}
+
+func (cmd ExecrCommand) Validate() error {
+	if cmd.Command == "" {
+		return errors.New("command is required")
+	}
+
+	return nil
+}
+
+// PassCommand passes the key with its modifiers to a certain window. Can be used
+// as a workaround for global keybinds not working on Wayland.
+type PassCommand struct {
+	Window WindowSelector
+}
+
+func (cmd PassCommand) String() string {
+	return dispatch("pass", cmd.Window.String())
+}
+
+func (cmd PassCommand) Validate() error {
+	return cmd.Window.check("window", true)
+}
+
+// SendShortcutCommand sends the key with the modifiers to a certain window, or the
+// active window when Window is empty. Modifiers are written like in a bind, for
+// example "SUPER SHIFT".
+type SendShortcutCommand struct {
+	Modifiers string
+	Key       string
+	Window    WindowSelector
+}
+
+func (cmd SendShortcutCommand) String() string {
+	if cmd.Window.IsZero() {
+		return dispatch("sendshortcut", fmt.Sprintf("%s, %s,", cmd.Modifiers, cmd.Key))
+	}
+
+	return dispatch("sendshortcut", fmt.Sprintf("%s, %s, %s", cmd.Modifiers, cmd.Key, cmd.Window.String()))
+}
+
+func (cmd SendShortcutCommand) Validate() error {
+	return cmd.Window.check("window", false)
+}
+
+// KillActiveCommand closes (not kills) the active window.
+type KillActiveCommand struct{}
+
+func (cmd KillActiveCommand) String() string {
+	return dispatch("killactive")
+}
+
+// ForceKillActiveCommand kills the active window.
+type ForceKillActiveCommand struct{}
+
+func (cmd ForceKillActiveCommand) String() string {
+	return dispatch("forcekillactive")
+}
+
+// CloseWindowCommand closes a specified window.
+type CloseWindowCommand struct {
+	Window WindowSelector
+}
+
+func (cmd CloseWindowCommand) String() string {
+	return dispatch("closewindow", cmd.Window.String())
+}
+
+func (cmd CloseWindowCommand) Validate() error {
+	return cmd.Window.check("window", true)
+}
+
+// SignalCommand sends a signal to the active window.
+type SignalCommand struct {
+	Signal int
+}
+
+func (cmd SignalCommand) String() string {
+	return dispatch("signal", strconv.Itoa(cmd.Signal))
+}
+
+// SignalWindowCommand sends a signal to a specified window.
+type SignalWindowCommand struct {
+	Window WindowSelector
+	Signal int
+}
+
+func (cmd SignalWindowCommand) String() string {
+	return dispatch("signalwindow", joinArgs(cmd.Window.String(), strconv.Itoa(cmd.Signal)))
+}
+
+func (cmd SignalWindowCommand) Validate() error {
+	return cmd.Window.check("window", true)
+}
+
+// WorkspaceCommand changes the workspace.
+type WorkspaceCommand struct {
+	Workspace WorkspaceSelector
+}
+
+func (cmd WorkspaceCommand) String() string {
+	return dispatch("workspace", cmd.Workspace.String())
+}
+
+func (cmd WorkspaceCommand) Validate() error {
+	return cmd.Workspace.check("workspace", true)
+}
+
+// MoveToWorkspaceCommand moves a window to a workspace. Window is optional and
+// defaults to the active window. A Silent move keeps the focus on the current
+// workspace.
+type MoveToWorkspaceCommand struct {
+	Workspace WorkspaceSelector
+	Window    WindowSelector
+	Silent    bool
+}
+
+func (cmd MoveToWorkspaceCommand) String() string {
+	dispatcher := "movetoworkspace"
+	if cmd.Silent {
+		dispatcher = "movetoworkspacesilent"
+	}
+
+	return dispatch(dispatcher, joinArgs(cmd.Workspace.String(), cmd.Window.String()))
+}
+
+func (cmd MoveToWorkspaceCommand) Validate() error {
+	return errors.Join(
+		cmd.Workspace.check("workspace", true),
+		cmd.Window.check("window", false),
+	)
+}
+
+// ToggleFloatingCommand toggles the floating state of a window. Window is optional
+// and defaults to the active window.
+type ToggleFloatingCommand struct {
+	Window WindowSelector
+}
+
+func (cmd ToggleFloatingCommand) String() string {
+	return dispatch("togglefloating", cmd.Window.orActive())
+}
+
+func (cmd ToggleFloatingCommand) Validate() error {
+	return cmd.Window.check("window", false)
+}
+
+// SetFloatingCommand sets a window to floating. Window is optional and defaults to
+// the active window.
+type SetFloatingCommand struct {
+	Window WindowSelector
+}
+
+func (cmd SetFloatingCommand) String() string {
+	return dispatch("setfloating", cmd.Window.orActive())
+}
+
+func (cmd SetFloatingCommand) Validate() error {
+	return cmd.Window.check("window", false)
+}
+
+// SetTiledCommand sets a window to tiled. Window is optional and defaults to the
+// active window.
+type SetTiledCommand struct {
+	Window WindowSelector
+}
+
+func (cmd SetTiledCommand) String() string {
+	return dispatch("settiled", cmd.Window.orActive())
+}
+
+func (cmd SetTiledCommand) Validate() error {
+	return cmd.Window.check("window", false)
+}
+
+// FullscreenCommand toggles the fullscreen state of the active window.
+type FullscreenCommand struct {
+	Mode FullscreenMode
+}
+
+func (cmd FullscreenCommand) String() string {
+	return dispatch("fullscreen", strconv.Itoa(int(cmd.Mode)))
+}
+
+// FullscreenStateCommand sets the internal fullscreen state of the active window
+// and the state communicated to the client independently.
+type FullscreenStateCommand struct {
+	Internal FullscreenState
+	Client   FullscreenState
+}
+
+func (cmd FullscreenStateCommand) String() string {
+	return dispatch("fullscreenstate", strconv.Itoa(int(cmd.Internal)), strconv.Itoa(int(cmd.Client)))
+}
+
+// DpmsCommand sets all monitors' DPMS status, or only the one of Monitor when set.
+type DpmsCommand struct {
+	Action  DpmsAction
+	Monitor MonitorSelector
+}
+
+func (cmd DpmsCommand) String() string {
+	return dispatch("dpms", string(cmd.Action), cmd.Monitor.String())
+}
+
+func (cmd DpmsCommand) Validate() error {
+	return cmd.Monitor.check("monitor", false)
+}
+
+// PinCommand pins a window, showing it on all workspaces. Window is optional and
+// defaults to the active window. Only works on floating windows.
+type PinCommand struct {
+	Window WindowSelector
+}
+
+func (cmd PinCommand) String() string {
+	return dispatch("pin", cmd.Window.orActive())
+}
+
+func (cmd PinCommand) Validate() error {
+	return cmd.Window.check("window", false)
+}
+
+// MoveFocusCommand moves the focus in a direction.
+type MoveFocusCommand struct {
+	Direction Direction
+}
+
+func (cmd MoveFocusCommand) String() string {
+	return dispatch("movefocus", string(cmd.Direction))
+}
+
+// MoveWindowCommand moves the active window in a direction, or to Monitor when set.
+type MoveWindowCommand struct {
+	Direction Direction
+	Monitor   MonitorSelector
+}
+
+func (cmd MoveWindowCommand) String() string {
+	if !cmd.Monitor.IsZero() {
+		return dispatch("movewindow", "mon:"+cmd.Monitor.String())
+	}
+
+	return dispatch("movewindow", string(cmd.Direction))
+}
+
+func (cmd MoveWindowCommand) Validate() error {
+	if cmd.Direction == "" && cmd.Monitor.IsZero() {
+		return errors.New("direction or monitor is required")
+	}
+
+	return cmd.Monitor.check("monitor", false)
+}
+
+// SwapWindowCommand swaps the active window with another window in a direction.
+type SwapWindowCommand struct {
+	Direction Direction
+}
+
+func (cmd SwapWindowCommand) String() string {
+	return dispatch("swapwindow", string(cmd.Direction))
+}
+
+// CenterWindowCommand centers the active floating window. With RespectReserved it
+// respects the reserved area of the monitor.
+type CenterWindowCommand struct {
+	RespectReserved bool
+}
+
+func (cmd CenterWindowCommand) String() string {
+	if cmd.RespectReserved {
+		return dispatch("centerwindow", "1")
+	}
+
+	return dispatch("centerwindow")
+}
+
+// ResizeActiveCommand resizes the active window.
+type ResizeActiveCommand struct {
+	Size Vector
+}
+
+func (cmd ResizeActiveCommand) String() string {
+	return dispatch("resizeactive", cmd.Size.String())
+}
+
+// MoveActiveCommand moves the active window.
+type MoveActiveCommand struct {
+	Offset Vector
+}
+
+func (cmd MoveActiveCommand) String() string {
+	return dispatch("moveactive", cmd.Offset.String())
+}
+
+// ResizeWindowPixelCommand resizes a selected window.
+type ResizeWindowPixelCommand struct {
+	Size   Vector
+	Window WindowSelector
+}
+
+func (cmd ResizeWindowPixelCommand) String() string {
+	return dispatch("resizewindowpixel", joinArgs(cmd.Size.String(), cmd.Window.String()))
+}
+
+func (cmd ResizeWindowPixelCommand) Validate() error {
+	return cmd.Window.check("window", true)
+}
+
+// MoveWindowPixelCommand moves a selected window.
+type MoveWindowPixelCommand struct {
+	Offset Vector
+	Window WindowSelector
+}
+
+func (cmd MoveWindowPixelCommand) String() string {
+	return dispatch("movewindowpixel", joinArgs(cmd.Offset.String(), cmd.Window.String()))
+}
+
+func (cmd MoveWindowPixelCommand) Validate() error {
+	return cmd.Window.check("window", true)
+}
+
+// CycleNextCommand focuses the next window on the workspace, or the previous one
+// when Previous is set.
+type CycleNextCommand struct {
+	Previous bool
+}
+
+func (cmd CycleNextCommand) String() string {
+	if cmd.Previous {
+		return dispatch("cyclenext", "prev")
+	}
+
+	return dispatch("cyclenext")
+}
+
+// SwapNextCommand swaps the focused window with the next window on the workspace,
+// or the previous one when Previous is set.
+type SwapNextCommand struct {
+	Previous bool
+}
+
+func (cmd SwapNextCommand) String() string {
+	if cmd.Previous {
+		return dispatch("swapnext", "prev")
+	}
+
+	return dispatch("swapnext")
+}
+
+// TagWindowCommand applies a tag to a window. Prefix the tag with + or - to set or
+// unset it instead of toggling it. Window is optional and defaults to the active
+// window.
+type TagWindowCommand struct {
+	Tag    string
+	Window WindowSelector
+}
+
+func (cmd TagWindowCommand) String() string {
+	return dispatch("tagwindow", cmd.Tag, cmd.Window.String())
+}
+
+func (cmd TagWindowCommand) Validate() error {
+	return cmd.Window.check("window", false)
+}
+
+// FocusWindowCommand focuses the first window matching Window.
+type FocusWindowCommand struct {
+	Window WindowSelector
+}
+
+func (cmd FocusWindowCommand) String() string {
+	return dispatch("focuswindow", cmd.Window.String())
+}
+
+func (cmd FocusWindowCommand) Validate() error {
+	return cmd.Window.check("window", true)
+}
+
+// FocusMonitorCommand focuses a monitor.
+type FocusMonitorCommand struct {
+	Monitor MonitorSelector
+}
+
+func (cmd FocusMonitorCommand) String() string {
+	return dispatch("focusmonitor", cmd.Monitor.String())
+}
+
+func (cmd FocusMonitorCommand) Validate() error {
+	return cmd.Monitor.check("monitor", true)
+}
+
+// SplitRatioCommand changes the split ratio of the active window. A relative ratio
+// is added to the current one, an Exact ratio replaces it.
+type SplitRatioCommand struct {
+	Ratio float64
+	Exact bool
+}
+
+func (cmd SplitRatioCommand) String() string {
+	ratio := strconv.FormatFloat(cmd.Ratio, 'f', -1, 64)
+	if cmd.Exact {
+		return dispatch("splitratio", "exact", ratio)
+	}
+
+	if cmd.Ratio >= 0 {
+		ratio = "+" + ratio
+	}
+
+	return dispatch("splitratio", ratio)
+}
+
+// MoveCursorToCornerCommand moves the cursor to a corner of the active window.
+// Corners are numbered 0 bottom left, 1 bottom right, 2 top right, 3 top left.
+type MoveCursorToCornerCommand struct {
+	Corner int
+}
+
+func (cmd MoveCursorToCornerCommand) String() string {
+	return dispatch("movecursortocorner", strconv.Itoa(cmd.Corner))
+}
+
+// MoveCursorCommand moves the cursor to a position in the layout.
+type MoveCursorCommand struct {
+	X int
+	Y int
+}
+
+func (cmd MoveCursorCommand) String() string {
+	return dispatch("movecursor", strconv.Itoa(cmd.X), strconv.Itoa(cmd.Y))
+}
+
+// RenameWorkspaceCommand renames a workspace. An empty Name resets it to the
+// default name.
+type RenameWorkspaceCommand struct {
+	ID   int
+	Name string
+}
+
+func (cmd RenameWorkspaceCommand) String() string {
+	return dispatch("renameworkspace", strconv.Itoa(cmd.ID), cmd.Name)
+}
+
+// ExitCommand exits the compositor with no questions asked.
+type ExitCommand struct{}
+
+func (cmd ExitCommand) String() string {
+	return dispatch("exit")
+}
+
+// MoveCurrentWorkspaceToMonitorCommand moves the active workspace to a monitor.
+type MoveCurrentWorkspaceToMonitorCommand struct {
+	Monitor MonitorSelector
+}
+
+func (cmd MoveCurrentWorkspaceToMonitorCommand) String() string {
+	return dispatch("movecurrentworkspacetomonitor", cmd.Monitor.String())
+}
+
+func (cmd MoveCurrentWorkspaceToMonitorCommand) Validate() error {
+	return cmd.Monitor.check("monitor", true)
+}
+
+// FocusWorkspaceOnCurrentMonitorCommand focuses the requested workspace on the
+// current monitor, swapping the current workspace to a different monitor if
+// necessary.
+type FocusWorkspaceOnCurrentMonitorCommand struct {
+	Workspace WorkspaceSelector
+}
+
+func (cmd FocusWorkspaceOnCurrentMonitorCommand) String() string {
+	return dispatch("focusworkspaceoncurrentmonitor", cmd.Workspace.String())
+}
+
+func (cmd FocusWorkspaceOnCurrentMonitorCommand) Validate() error {
+	return cmd.Workspace.check("workspace", true)
+}
+
+// MoveWorkspaceToMonitorCommand moves a workspace to a monitor.
+type MoveWorkspaceToMonitorCommand struct {
+	Workspace WorkspaceSelector
+	Monitor   MonitorSelector
+}
+
+func (cmd MoveWorkspaceToMonitorCommand) String() string {
+	return dispatch("moveworkspacetomonitor", cmd.Workspace.String(), cmd.Monitor.String())
+}
+
+func (cmd MoveWorkspaceToMonitorCommand) Validate() error {
+	return errors.Join(
+		cmd.Workspace.check("workspace", true),
+		cmd.Monitor.check("monitor", true),
+	)
+}
+
+// SwapActiveWorkspacesCommand swaps the active workspaces between two monitors.
+type SwapActiveWorkspacesCommand struct {
+	First  MonitorSelector
+	Second MonitorSelector
+}
+
+func (cmd SwapActiveWorkspacesCommand) String() string {
+	return dispatch("swapactiveworkspaces", cmd.First.String(), cmd.Second.String())
+}
+
+func (cmd SwapActiveWorkspacesCommand) Validate() error {
+	return errors.Join(
+		cmd.First.check("first monitor", true),
+		cmd.Second.check("second monitor", true),
+	)
+}
+
+// BringActiveToTopCommand brings the current window to the top of the stack.
+type BringActiveToTopCommand struct{}
+
+func (cmd BringActiveToTopCommand) String() string {
+	return dispatch("bringactivetotop")
+}
+
+// ToggleSpecialWorkspaceCommand toggles a special workspace on or off. Name is
+// optional and defaults to the unnamed special workspace.
+type ToggleSpecialWorkspaceCommand struct {
+	Name string
+}
+
+func (cmd ToggleSpecialWorkspaceCommand) String() string {
+	return dispatch("togglespecialworkspace", cmd.Name)
+}
+
+// FocusUrgentOrLastCommand focuses the urgent window or the last window.
+type FocusUrgentOrLastCommand struct{}
+
+func (cmd FocusUrgentOrLastCommand) String() string {
+	return dispatch("focusurgentorlast")
+}
+
+// FocusCurrentOrLastCommand switches focus from the current window to the last
+// window.
+type FocusCurrentOrLastCommand struct{}
+
+func (cmd FocusCurrentOrLastCommand) String() string {
+	return dispatch("focuscurrentorlast")
+}
+
+// ToggleGroupCommand toggles the current active window into a group.
+type ToggleGroupCommand struct{}
+
+func (cmd ToggleGroupCommand) String() string {
+	return dispatch("togglegroup")
+}
+
+// ChangeGroupActiveCommand switches to the next window in a group, or the previous
+// one when Backward is set. An Index above 0 switches to the window at that
+// position instead, starting at 1.
+type ChangeGroupActiveCommand struct {
+	Backward bool
+	Index    int
+}
+
+func (cmd ChangeGroupActiveCommand) String() string {
+	if cmd.Index > 0 {
+		return dispatch("changegroupactive", strconv.Itoa(cmd.Index))
+	}
+
+	if cmd.Backward {
+		return dispatch("changegroupactive", "b")
+	}
+
+	return dispatch("changegroupactive", "f")
+}
+
+// LockGroupsCommand locks the groups, so all groups will not accept new windows
+// or have windows moved out of them.
+type LockGroupsCommand struct {
+	Action LockAction
+}
+
+func (cmd LockGroupsCommand) String() string {
+	return dispatch("lockgroups", string(cmd.Action))
+}
+
+// LockActiveGroupCommand locks the current group.
+type LockActiveGroupCommand struct {
+	Action LockAction
+}
+
+func (cmd LockActiveGroupCommand) String() string {
+	return dispatch("lockactivegroup", string(cmd.Action))
+}
+
+// MoveIntoGroupCommand moves the active window into the group in a direction.
+type MoveIntoGroupCommand struct {
+	Direction Direction
+}
+
+func (cmd MoveIntoGroupCommand) String() string {
+	return dispatch("moveintogroup", string(cmd.Direction))
+}
+
+// MoveOutOfGroupCommand moves a window out of its group. Window is optional and
+// defaults to the active window.
+type MoveOutOfGroupCommand struct {
+	Window WindowSelector
+}
+
+func (cmd MoveOutOfGroupCommand) String() string {
+	return dispatch("moveoutofgroup", cmd.Window.orActive())
+}
+
+func (cmd MoveOutOfGroupCommand) Validate() error {
+	return cmd.Window.check("window", false)
+}
+
+// SubmapCommand changes the current keybind submap. "reset" returns to the
+// default submap.
+type SubmapCommand struct {
+	Name string
+}
+
+func (cmd SubmapCommand) String() string {
+	return dispatch("submap", cmd.Name)
+}
+
+func (cmd SubmapCommand) Validate() error {
+	if cmd.Name == "" {
+		return errors.New("submap name is required")
+	}
+
+	return nil
+}
+
+// GlobalCommand executes a global shortcut with the given name, formatted as
+// "appid:name".
+type GlobalCommand struct {
+	Name string
+}
+
+func (cmd GlobalCommand) String() string {
+	return dispatch("global", cmd.Name)
+}
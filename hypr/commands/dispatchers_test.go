@@ -0,0 +1,40 @@
+package commands
+
+import "testing"
+
+var dispatcherTests = map[string]Command{
+	"dispatch exec kitty":                                     ExecCommand{Command: "kitty"},
+	"dispatch exec [workspace 2 silent] kitty":                ExecCommand{Rules: "[workspace 2 silent]", Command: "kitty"},
+	"dispatch killactive unused":                              KillActiveCommand{},
+	"dispatch workspace 2":                                    WorkspaceCommand{Workspace: WorkspaceByID(2)},
+	"dispatch movetoworkspace 2":                              MoveToWorkspaceCommand{Workspace: WorkspaceByID(2)},
+	"dispatch movetoworkspacesilent 2,address:0x62c8246947c0": MoveToWorkspaceCommand{Workspace: WorkspaceByID(2), Window: WindowByAddress("62c8246947c0"), Silent: true},
+	"dispatch togglefloating active":                          ToggleFloatingCommand{},
+	"dispatch togglefloating class:kitty":                     ToggleFloatingCommand{Window: WindowByClass("kitty")},
+	"dispatch pin active":                                     PinCommand{},
+	"dispatch moveoutofgroup active":                          MoveOutOfGroupCommand{},
+	"dispatch fullscreen 1":                                   FullscreenCommand{Mode: FullscreenModeMaximize},
+	"dispatch fullscreenstate 2 -1":                           FullscreenStateCommand{Internal: FullscreenStateFullscreen, Client: FullscreenStateCurrent},
+	"dispatch movefocus l":                                    MoveFocusCommand{Direction: DirectionLeft},
+	"dispatch movewindow mon:DP-1":                            MoveWindowCommand{Monitor: MonitorByName("DP-1")},
+	"dispatch resizeactive 10 -10":                            ResizeActiveCommand{Size: Relative(10, -10)},
+	"dispatch resizewindowpixel exact 50% 50%,class:kitty":    ResizeWindowPixelCommand{Size: Vector{X: 50, Y: 50, Exact: true, Percent: true}, Window: WindowByClass("kitty")},
+	"dispatch movewindowpixel exact 100 100,class:kitty":      MoveWindowPixelCommand{Offset: Exact(100, 100), Window: WindowByClass("kitty")},
+	"dispatch centerwindow 1":                                 CenterWindowCommand{RespectReserved: true},
+	"dispatch changegroupactive b":                            ChangeGroupActiveCommand{Backward: true},
+	"dispatch renameworkspace 1 web":                          RenameWorkspaceCommand{ID: 1, Name: "web"},
+	"dispatch sendshortcut SUPER, F4, class:kitty":            SendShortcutCommand{Modifiers: "SUPER", Key: "F4", Window: WindowByClass("kitty")},
+	"dispatch splitratio +0.1":                                SplitRatioCommand{Ratio: 0.1},
+	"dispatch splitratio exact 0.5":                           SplitRatioCommand{Ratio: 0.5, Exact: true},
+	"dispatch movecurrentworkspacetomonitor DP-1":             MoveCurrentWorkspaceToMonitorCommand{Monitor: MonitorByName("DP-1")},
+	"dispatch swapactiveworkspaces DP-1 DP-2":                 SwapActiveWorkspacesCommand{First: MonitorByName("DP-1"), Second: MonitorByName("DP-2")},
+	"dispatch dpms off":                                       DpmsCommand{Action: DpmsOff},
+}
+
+func TestDispatcherString(t *testing.T) {
+	for expected, command := range dispatcherTests {
+		if result := command.String(); result != expected {
+			t.Errorf("%T: expected %q, got %q", command, expected, result)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// selector holds the encoded form of a selector along with any error found while
+// building it, which is reported by Validate.
+type selector struct {
+	value string
+	err   error
+}
+
+// String returns the selector as Hyprland expects it in dispatcher arguments.
+func (s selector) String() string {
+	return s.value
+}
+
+// Validate returns the error found while building the selector, if any.
+func (s selector) Validate() error {
+	return s.err
+}
+
+// IsZero reports whether no selector was set.
+func (s selector) IsZero() bool {
+	return s.value == "" && s.err == nil
+}
+
+func regexSelector(prefix string, pattern string) selector {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return selector{value: prefix + pattern, err: fmt.Errorf("invalid %s regex: %v", strings.TrimSuffix(prefix, ":"), err)}
+	}
+
+	return selector{value: prefix + pattern}
+}
+
+func relative(prefix string, offset int) string {
+	if offset >= 0 {
+		return fmt.Sprintf("%s+%d", prefix, offset)
+	}
+
+	return fmt.Sprintf("%s%d", prefix, offset)
+}
+
+// WindowSelector selects a window for dispatchers that take one. The zero value
+// selects nothing, which dispatchers with an optional window treat as the active
+// window.
+type WindowSelector struct {
+	selector
+}
+
+// WindowByClass selects the first window whose class matches the regex.
+func WindowByClass(pattern string) WindowSelector {
+	return WindowSelector{regexSelector("class:", pattern)}
+}
+
+// WindowByInitialClass selects the first window whose initial class matches the regex.
+func WindowByInitialClass(pattern string) WindowSelector {
+	return WindowSelector{regexSelector("initialclass:", pattern)}
+}
+
+// WindowByTitle selects the first window whose title matches the regex.
+func WindowByTitle(pattern string) WindowSelector {
+	return WindowSelector{regexSelector("title:", pattern)}
+}
+
+// WindowByInitialTitle selects the first window whose initial title matches the regex.
+func WindowByInitialTitle(pattern string) WindowSelector {
+	return WindowSelector{regexSelector("initialtitle:", pattern)}
+}
+
+// WindowByTag selects the first window with the given tag.
+func WindowByTag(tag string) WindowSelector {
+	if tag == "" {
+		return WindowSelector{selector{value: "tag:", err: errors.New("window tag is empty")}}
+	}
+
+	return WindowSelector{selector{value: "tag:" + tag}}
+}
+
+// WindowByPID selects the window of the process with the given PID.
+func WindowByPID(pid int) WindowSelector {
+	value := "pid:" + strconv.Itoa(pid)
+	if pid <= 0 {
+		return WindowSelector{selector{value: value, err: fmt.Errorf("invalid window pid %d", pid)}}
+	}
+
+	return WindowSelector{selector{value: value}}
+}
+
+// WindowByAddress selects the window with the given address. Both the form used in
+// events, "62c8246947c0", and the one used in queries, "0x62c8246947c0", are
+// accepted.
+func WindowByAddress(address string) WindowSelector {
+	hex := strings.TrimPrefix(strings.ToLower(address), "0x")
+	value := "address:0x" + hex
+
+	if _, err := strconv.ParseUint(hex, 16, 64); err != nil {
+		return WindowSelector{selector{value: value, err: fmt.Errorf("invalid window address %q", address)}}
+	}
+
+	return WindowSelector{selector{value: value}}
+}
+
+// FloatingWindow selects the first floating window on the current workspace.
+func FloatingWindow() WindowSelector {
+	return WindowSelector{selector{value: "floating"}}
+}
+
+// TiledWindow selects the first tiled window on the current workspace.
+func TiledWindow() WindowSelector {
+	return WindowSelector{selector{value: "tiled"}}
+}
+
+// ActiveWindow selects the active window.
+func ActiveWindow() WindowSelector {
+	return WindowSelector{selector{value: "activewindow"}}
+}
+
+// orActive renders the selector for dispatchers whose window is optional. Hyprland
+// only falls back to the active window for an empty argument or "active", so the
+// zero WindowSelector renders as "active".
+func (s WindowSelector) orActive() string {
+	if s.IsZero() {
+		return "active"
+	}
+
+	return s.String()
+}
+
+// WorkspaceSelector selects a workspace for dispatchers that take one.
+type WorkspaceSelector struct {
+	selector
+}
+
+// WorkspaceByID selects the workspace with the given ID.
+func WorkspaceByID(id int) WorkspaceSelector {
+	value := strconv.Itoa(id)
+	if id <= 0 {
+		return WorkspaceSelector{selector{value: value, err: fmt.Errorf("invalid workspace id %d", id)}}
+	}
+
+	return WorkspaceSelector{selector{value: value}}
+}
+
+// WorkspaceRelative selects the workspace offset IDs away from the current one,
+// e.g. +1 or -3.
+func WorkspaceRelative(offset int) WorkspaceSelector {
+	return WorkspaceSelector{selector{value: relative("", offset)}}
+}
+
+// WorkspaceOnMonitor selects the workspace offset positions away from the current
+// one among the open workspaces on the current monitor.
+func WorkspaceOnMonitor(offset int) WorkspaceSelector {
+	return WorkspaceSelector{selector{value: relative("m", offset)}}
+}
+
+// WorkspaceOnMonitorIncludingEmpty is like WorkspaceOnMonitor but also counts
+// empty workspaces.
+func WorkspaceOnMonitorIncludingEmpty(offset int) WorkspaceSelector {
+	return WorkspaceSelector{selector{value: relative("r", offset)}}
+}
+
+// WorkspaceOpen selects the workspace offset positions away from the current one
+// among all open workspaces.
+func WorkspaceOpen(offset int) WorkspaceSelector {
+	return WorkspaceSelector{selector{value: relative("e", offset)}}
+}
+
+// WorkspaceByName selects the workspace with the given name.
+func WorkspaceByName(name string) WorkspaceSelector {
+	value := "name:" + name
+	if name == "" || strings.ContainsAny(name, ",;\n") {
+		return WorkspaceSelector{selector{value: value, err: fmt.Errorf("invalid workspace name %q", name)}}
+	}
+
+	return WorkspaceSelector{selector{value: value}}
+}
+
+// PreviousWorkspace selects the previously active workspace.
+func PreviousWorkspace() WorkspaceSelector {
+	return WorkspaceSelector{selector{value: "previous"}}
+}
+
+// PreviousWorkspacePerMonitor selects the previously active workspace on the
+// current monitor.
+func PreviousWorkspacePerMonitor() WorkspaceSelector {
+	return WorkspaceSelector{selector{value: "previous_per_monitor"}}
+}
+
+// EmptyWorkspace selects the first available empty workspace. With onMonitor it
+// only searches the current monitor, with next it picks the next empty workspace
+// after the current one instead of the first one.
+func EmptyWorkspace(onMonitor bool, next bool) WorkspaceSelector {
+	value := "empty"
+	if onMonitor {
+		value += "m"
+	}
+
+	if next {
+		value += "n"
+	}
+
+	return WorkspaceSelector{selector{value: value}}
+}
+
+// SpecialWorkspace selects a special workspace. An empty name selects the unnamed
+// special workspace.
+func SpecialWorkspace(name string) WorkspaceSelector {
+	if name == "" {
+		return WorkspaceSelector{selector{value: "special"}}
+	}
+
+	value := "special:" + name
+	if strings.ContainsAny(name, ",;\n") {
+		return WorkspaceSelector{selector{value: value, err: fmt.Errorf("invalid special workspace name %q", name)}}
+	}
+
+	return WorkspaceSelector{selector{value: value}}
+}
+
+// MonitorSelector selects a monitor for dispatchers that take one.
+type MonitorSelector struct {
+	selector
+}
+
+// MonitorByName selects the monitor with the given name, e.g. "DP-1".
+func MonitorByName(name string) MonitorSelector {
+	if name == "" || strings.ContainsAny(name, " ,;\n") {
+		return MonitorSelector{selector{value: name, err: fmt.Errorf("invalid monitor name %q", name)}}
+	}
+
+	return MonitorSelector{selector{value: name}}
+}
+
+// MonitorByID selects the monitor with the given ID.
+func MonitorByID(id int) MonitorSelector {
+	value := strconv.Itoa(id)
+	if id < 0 {
+		return MonitorSelector{selector{value: value, err: fmt.Errorf("invalid monitor id %d", id)}}
+	}
+
+	return MonitorSelector{selector{value: value}}
+}
+
+// MonitorByDescription selects the monitor with the given description, as shown by
+// GetMonitors.
+func MonitorByDescription(description string) MonitorSelector {
+	value := "desc:" + description
+	if description == "" || strings.ContainsAny(description, ",;\n") {
+		return MonitorSelector{selector{value: value, err: fmt.Errorf("invalid monitor description %q", description)}}
+	}
+
+	return MonitorSelector{selector{value: value}}
+}
+
+// MonitorInDirection selects the monitor in a direction from the current one.
+func MonitorInDirection(direction Direction) MonitorSelector {
+	switch direction {
+	case DirectionLeft, DirectionRight, DirectionUp, DirectionDown:
+		return MonitorSelector{selector{value: string(direction)}}
+	default:
+		return MonitorSelector{selector{value: string(direction), err: fmt.Errorf("invalid direction %q", direction)}}
+	}
+}
+
+// MonitorRelative selects the monitor offset positions away from the current one.
+func MonitorRelative(offset int) MonitorSelector {
+	return MonitorSelector{selector{value: relative("", offset)}}
+}
+
+// CurrentMonitor selects the current monitor.
+func CurrentMonitor() MonitorSelector {
+	return MonitorSelector{selector{value: "current"}}
+}
+
+// check validates a selector of a command. Required selectors fail when they were
+// left empty.
+func (s selector) check(name string, required bool) error {
+	if required && s.IsZero() {
+		return fmt.Errorf("%s is required", name)
+	}
+
+	if s.err != nil {
+		return fmt.Errorf("%s: %w", name, s.err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package commands
+
+import "testing"
+
+var selectorTests = map[string]interface {
+	String() string
+	Validate() error
+}{
+	"class:^(kitty)$":            WindowByClass("^(kitty)$"),
+	"initialtitle:.*Firefox":     WindowByInitialTitle(".*Firefox"),
+	"tag:media":                  WindowByTag("media"),
+	"pid:1234":                   WindowByPID(1234),
+	"address:0x62c8246947c0":     WindowByAddress("62c8246947c0"),
+	"address:0x62c8246947c1":     WindowByAddress("0x62C8246947C1"),
+	"floating":                   FloatingWindow(),
+	"activewindow":               ActiveWindow(),
+	"3":                          WorkspaceByID(3),
+	"+1":                         WorkspaceRelative(1),
+	"m-1":                        WorkspaceOnMonitor(-1),
+	"r+2":                        WorkspaceOnMonitorIncludingEmpty(2),
+	"e-2":                        WorkspaceOpen(-2),
+	"name:web":                   WorkspaceByName("web"),
+	"special":                    SpecialWorkspace(""),
+	"special:scratchpad":         SpecialWorkspace("scratchpad"),
+	"previous_per_monitor":       PreviousWorkspacePerMonitor(),
+	"emptymn":                    EmptyWorkspace(true, true),
+	"DP-1":                       MonitorByName("DP-1"),
+	"desc:Dell Inc. DELL U2720Q": MonitorByDescription("Dell Inc. DELL U2720Q"),
+	"l":                          MonitorInDirection(DirectionLeft),
+	"-1":                         MonitorRelative(-1),
+	"current":                    CurrentMonitor(),
+}
+
+func TestSelectorString(t *testing.T) {
+	for expected, selector := range selectorTests {
+		if err := selector.Validate(); err != nil {
+			t.Errorf("%q: unexpected error %v", expected, err)
+		}
+
+		if result := selector.String(); result != expected {
+			t.Errorf("expected %q, got %q", expected, result)
+		}
+	}
+}
+
+func TestSelectorValidate(t *testing.T) {
+	invalid := map[string]interface{ Validate() error }{
+		"regex":        WindowByClass("(kitty"),
+		"address":      WindowByAddress("0xnothex"),
+		"pid":          WindowByPID(0),
+		"workspace id": WorkspaceByID(0),
+		"name":         WorkspaceByName("a,b"),
+		"special":      SpecialWorkspace("a;b"),
+		"monitor":      MonitorByName(""),
+		"direction":    MonitorInDirection("x"),
+	}
+
+	for name, selector := range invalid {
+		if selector.Validate() == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCommandValidateRequiresSelector(t *testing.T) {
+	if err := (WorkspaceCommand{}).Validate(); err == nil {
+		t.Error("expected an error for a missing workspace")
+	}
+
+	for _, command := range []Validator{ExecCommand{}, ExecrCommand{}, SubmapCommand{}} {
+		if err := command.Validate(); err == nil {
+			t.Errorf("%T: expected an error for a missing command or name", command)
+		}
+	}
+
+	if err := (MoveWindowCommand{}).Validate(); err == nil {
+		t.Error("expected an error for a missing direction and monitor")
+	}
+
+	if err := (MoveWindowCommand{Direction: DirectionLeft}).Validate(); err != nil {
+		t.Errorf("expected a direction to be enough, got %v", err)
+	}
+
+	if err := (MoveToWorkspaceCommand{Workspace: WorkspaceByID(1)}).Validate(); err != nil {
+		t.Errorf("expected the window to be optional, got %v", err)
+	}
+
+	if err := (MoveToWorkspaceCommand{Workspace: WorkspaceByID(1), Window: WindowByPID(-1)}).Validate(); err == nil {
+		t.Error("expected an error for an invalid window")
+	}
+}
@@ -0,0 +1,422 @@
+package hypr
+
+import "github.com/jstncnnr/go-hyprland/hypr/commands"
+
+// Exec executes a shell command.
+func (req *Request) Exec(command string) *Request {
+	return req.AddCommand(commands.ExecCommand{
+		Command: command,
+	})
+}
+
+// ExecWithRules executes a shell command and applies the window rules to the first
+// window it opens, e.g. "[workspace 2 silent; float]".
+func (req *Request) ExecWithRules(rules string, command string) *Request {
+	return req.AddCommand(commands.ExecCommand{
+		Rules:   rules,
+		Command: command,
+	})
+}
+
+// Execr executes a raw shell command without support for window rules.
+func (req *Request) Execr(command string) *Request {
+	return req.AddCommand(commands.ExecrCommand{
+		Command: command,
+	})
+}
+
+// Pass passes the key with its modifiers to a certain window.
+func (req *Request) Pass(window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.PassCommand{
+		Window: window,
+	})
+}
+
+// SendShortcut sends the key with the modifiers to a certain window, or the active
+// window when window is the zero WindowSelector.
+func (req *Request) SendShortcut(modifiers string, key string, window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.SendShortcutCommand{
+		Modifiers: modifiers,
+		Key:       key,
+		Window:    window,
+	})
+}
+
+// KillActive closes (not kills) the active window.
+func (req *Request) KillActive() *Request {
+	return req.AddCommand(commands.KillActiveCommand{})
+}
+
+// ForceKillActive kills the active window.
+func (req *Request) ForceKillActive() *Request {
+	return req.AddCommand(commands.ForceKillActiveCommand{})
+}
+
+// CloseWindow closes a specified window.
+func (req *Request) CloseWindow(window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.CloseWindowCommand{
+		Window: window,
+	})
+}
+
+// Signal sends a signal to the active window.
+func (req *Request) Signal(signal int) *Request {
+	return req.AddCommand(commands.SignalCommand{
+		Signal: signal,
+	})
+}
+
+// SignalWindow sends a signal to a specified window.
+func (req *Request) SignalWindow(window commands.WindowSelector, signal int) *Request {
+	return req.AddCommand(commands.SignalWindowCommand{
+		Window: window,
+		Signal: signal,
+	})
+}
+
+// Workspace changes the workspace.
+func (req *Request) Workspace(workspace commands.WorkspaceSelector) *Request {
+	return req.AddCommand(commands.WorkspaceCommand{
+		Workspace: workspace,
+	})
+}
+
+// MoveToWorkspace moves a window to a workspace and follows it. The zero
+// WindowSelector moves the active window.
+func (req *Request) MoveToWorkspace(workspace commands.WorkspaceSelector, window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.MoveToWorkspaceCommand{
+		Workspace: workspace,
+		Window:    window,
+	})
+}
+
+// MoveToWorkspaceSilent moves a window to a workspace without following it. The
+// zero WindowSelector moves the active window.
+func (req *Request) MoveToWorkspaceSilent(workspace commands.WorkspaceSelector, window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.MoveToWorkspaceCommand{
+		Workspace: workspace,
+		Window:    window,
+		Silent:    true,
+	})
+}
+
+// ToggleFloating toggles the floating state of a window. The zero WindowSelector
+// toggles the active window.
+func (req *Request) ToggleFloating(window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.ToggleFloatingCommand{
+		Window: window,
+	})
+}
+
+// SetFloating sets a window to floating. The zero WindowSelector sets the active window.
+func (req *Request) SetFloating(window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.SetFloatingCommand{
+		Window: window,
+	})
+}
+
+// SetTiled sets a window to tiled. The zero WindowSelector sets the active window.
+func (req *Request) SetTiled(window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.SetTiledCommand{
+		Window: window,
+	})
+}
+
+// Fullscreen toggles the fullscreen state of the active window.
+func (req *Request) Fullscreen(mode commands.FullscreenMode) *Request {
+	return req.AddCommand(commands.FullscreenCommand{
+		Mode: mode,
+	})
+}
+
+// FullscreenState sets the internal fullscreen state of the active window and the
+// state communicated to the client independently.
+func (req *Request) FullscreenState(internal commands.FullscreenState, client commands.FullscreenState) *Request {
+	return req.AddCommand(commands.FullscreenStateCommand{
+		Internal: internal,
+		Client:   client,
+	})
+}
+
+// Dpms sets the DPMS status of a monitor, or of all monitors for the zero
+// MonitorSelector.
+func (req *Request) Dpms(action commands.DpmsAction, monitor commands.MonitorSelector) *Request {
+	return req.AddCommand(commands.DpmsCommand{
+		Action:  action,
+		Monitor: monitor,
+	})
+}
+
+// Pin pins a floating window, showing it on all workspaces. The zero
+// WindowSelector pins the active window.
+func (req *Request) Pin(window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.PinCommand{
+		Window: window,
+	})
+}
+
+// MoveFocus moves the focus in a direction.
+func (req *Request) MoveFocus(direction commands.Direction) *Request {
+	return req.AddCommand(commands.MoveFocusCommand{
+		Direction: direction,
+	})
+}
+
+// MoveWindow moves the active window in a direction.
+func (req *Request) MoveWindow(direction commands.Direction) *Request {
+	return req.AddCommand(commands.MoveWindowCommand{
+		Direction: direction,
+	})
+}
+
+// MoveWindowToMonitor moves the active window to a monitor.
+func (req *Request) MoveWindowToMonitor(monitor commands.MonitorSelector) *Request {
+	return req.AddCommand(commands.MoveWindowCommand{
+		Monitor: monitor,
+	})
+}
+
+// SwapWindow swaps the active window with another window in a direction.
+func (req *Request) SwapWindow(direction commands.Direction) *Request {
+	return req.AddCommand(commands.SwapWindowCommand{
+		Direction: direction,
+	})
+}
+
+// CenterWindow centers the active floating window, optionally respecting the
+// reserved area of the monitor.
+func (req *Request) CenterWindow(respectReserved bool) *Request {
+	return req.AddCommand(commands.CenterWindowCommand{
+		RespectReserved: respectReserved,
+	})
+}
+
+// ResizeActive resizes the active window.
+func (req *Request) ResizeActive(size commands.Vector) *Request {
+	return req.AddCommand(commands.ResizeActiveCommand{
+		Size: size,
+	})
+}
+
+// MoveActive moves the active window.
+func (req *Request) MoveActive(offset commands.Vector) *Request {
+	return req.AddCommand(commands.MoveActiveCommand{
+		Offset: offset,
+	})
+}
+
+// ResizeWindowPixel resizes a selected window.
+func (req *Request) ResizeWindowPixel(size commands.Vector, window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.ResizeWindowPixelCommand{
+		Size:   size,
+		Window: window,
+	})
+}
+
+// MoveWindowPixel moves a selected window.
+func (req *Request) MoveWindowPixel(offset commands.Vector, window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.MoveWindowPixelCommand{
+		Offset: offset,
+		Window: window,
+	})
+}
+
+// CycleNext focuses the next window on the workspace.
+func (req *Request) CycleNext() *Request {
+	return req.AddCommand(commands.CycleNextCommand{})
+}
+
+// CyclePrev focuses the previous window on the workspace.
+func (req *Request) CyclePrev() *Request {
+	return req.AddCommand(commands.CycleNextCommand{
+		Previous: true,
+	})
+}
+
+// SwapNext swaps the focused window with the next window on the workspace.
+func (req *Request) SwapNext() *Request {
+	return req.AddCommand(commands.SwapNextCommand{})
+}
+
+// SwapPrev swaps the focused window with the previous window on the workspace.
+func (req *Request) SwapPrev() *Request {
+	return req.AddCommand(commands.SwapNextCommand{
+		Previous: true,
+	})
+}
+
+// TagWindow applies a tag to a window. The zero WindowSelector tags the active window.
+func (req *Request) TagWindow(tag string, window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.TagWindowCommand{
+		Tag:    tag,
+		Window: window,
+	})
+}
+
+// FocusWindow focuses the first window matching window.
+func (req *Request) FocusWindow(window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.FocusWindowCommand{
+		Window: window,
+	})
+}
+
+// FocusMonitor focuses a monitor.
+func (req *Request) FocusMonitor(monitor commands.MonitorSelector) *Request {
+	return req.AddCommand(commands.FocusMonitorCommand{
+		Monitor: monitor,
+	})
+}
+
+// SplitRatio changes the split ratio of the active window by ratio.
+func (req *Request) SplitRatio(ratio float64) *Request {
+	return req.AddCommand(commands.SplitRatioCommand{
+		Ratio: ratio,
+	})
+}
+
+// SetSplitRatio sets the split ratio of the active window.
+func (req *Request) SetSplitRatio(ratio float64) *Request {
+	return req.AddCommand(commands.SplitRatioCommand{
+		Ratio: ratio,
+		Exact: true,
+	})
+}
+
+// MoveCursorToCorner moves the cursor to a corner of the active window.
+func (req *Request) MoveCursorToCorner(corner int) *Request {
+	return req.AddCommand(commands.MoveCursorToCornerCommand{
+		Corner: corner,
+	})
+}
+
+// MoveCursor moves the cursor to a position in the layout.
+func (req *Request) MoveCursor(x int, y int) *Request {
+	return req.AddCommand(commands.MoveCursorCommand{
+		X: x,
+		Y: y,
+	})
+}
+
+// RenameWorkspace renames a workspace. An empty name resets it to the default name.
+func (req *Request) RenameWorkspace(id int, name string) *Request {
+	return req.AddCommand(commands.RenameWorkspaceCommand{
+		ID:   id,
+		Name: name,
+	})
+}
+
+// Exit exits the compositor with no questions asked.
+func (req *Request) Exit() *Request {
+	return req.AddCommand(commands.ExitCommand{})
+}
+
+// MoveCurrentWorkspaceToMonitor moves the active workspace to a monitor.
+func (req *Request) MoveCurrentWorkspaceToMonitor(monitor commands.MonitorSelector) *Request {
+	return req.AddCommand(commands.MoveCurrentWorkspaceToMonitorCommand{
+		Monitor: monitor,
+	})
+}
+
+// FocusWorkspaceOnCurrentMonitor focuses the requested workspace on the current
+// monitor, swapping the current workspace to a different monitor if necessary.
+func (req *Request) FocusWorkspaceOnCurrentMonitor(workspace commands.WorkspaceSelector) *Request {
+	return req.AddCommand(commands.FocusWorkspaceOnCurrentMonitorCommand{
+		Workspace: workspace,
+	})
+}
+
+// MoveWorkspaceToMonitor moves a workspace to a monitor.
+func (req *Request) MoveWorkspaceToMonitor(workspace commands.WorkspaceSelector, monitor commands.MonitorSelector) *Request {
+	return req.AddCommand(commands.MoveWorkspaceToMonitorCommand{
+		Workspace: workspace,
+		Monitor:   monitor,
+	})
+}
+
+// SwapActiveWorkspaces swaps the active workspaces between two monitors.
+func (req *Request) SwapActiveWorkspaces(first commands.MonitorSelector, second commands.MonitorSelector) *Request {
+	return req.AddCommand(commands.SwapActiveWorkspacesCommand{
+		First:  first,
+		Second: second,
+	})
+}
+
+// BringActiveToTop brings the current window to the top of the stack.
+func (req *Request) BringActiveToTop() *Request {
+	return req.AddCommand(commands.BringActiveToTopCommand{})
+}
+
+// ToggleSpecialWorkspace toggles a special workspace on or off. An empty name
+// toggles the unnamed special workspace.
+func (req *Request) ToggleSpecialWorkspace(name string) *Request {
+	return req.AddCommand(commands.ToggleSpecialWorkspaceCommand{
+		Name: name,
+	})
+}
+
+// FocusUrgentOrLast focuses the urgent window or the last window.
+func (req *Request) FocusUrgentOrLast() *Request {
+	return req.AddCommand(commands.FocusUrgentOrLastCommand{})
+}
+
+// FocusCurrentOrLast switches focus from the current window to the last window.
+func (req *Request) FocusCurrentOrLast() *Request {
+	return req.AddCommand(commands.FocusCurrentOrLastCommand{})
+}
+
+// ToggleGroup toggles the current active window into a group.
+func (req *Request) ToggleGroup() *Request {
+	return req.AddCommand(commands.ToggleGroupCommand{})
+}
+
+// ChangeGroupActive switches to the next window in a group, or the previous one
+// when backward is set.
+func (req *Request) ChangeGroupActive(backward bool) *Request {
+	return req.AddCommand(commands.ChangeGroupActiveCommand{
+		Backward: backward,
+	})
+}
+
+// LockGroups locks the groups, so all groups will not accept new windows or have
+// windows moved out of them.
+func (req *Request) LockGroups(action commands.LockAction) *Request {
+	return req.AddCommand(commands.LockGroupsCommand{
+		Action: action,
+	})
+}
+
+// LockActiveGroup locks the current group.
+func (req *Request) LockActiveGroup(action commands.LockAction) *Request {
+	return req.AddCommand(commands.LockActiveGroupCommand{
+		Action: action,
+	})
+}
+
+// MoveIntoGroup moves the active window into the group in a direction.
+func (req *Request) MoveIntoGroup(direction commands.Direction) *Request {
+	return req.AddCommand(commands.MoveIntoGroupCommand{
+		Direction: direction,
+	})
+}
+
+// MoveOutOfGroup moves a window out of its group. The zero WindowSelector moves
+// the active window.
+func (req *Request) MoveOutOfGroup(window commands.WindowSelector) *Request {
+	return req.AddCommand(commands.MoveOutOfGroupCommand{
+		Window: window,
+	})
+}
+
+// Submap changes the current keybind submap. "reset" returns to the default submap.
+func (req *Request) Submap(name string) *Request {
+	return req.AddCommand(commands.SubmapCommand{
+		Name: name,
+	})
+}
+
+// Global executes a global shortcut with the given name, formatted as "appid:name".
+func (req *Request) Global(name string) *Request {
+	return req.AddCommand(commands.GlobalCommand{
+		Name: name,
+	})
+}
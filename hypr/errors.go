@@ -0,0 +1,85 @@
+package hypr
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jstncnnr/go-hyprland/hypr/commands"
+	"github.com/jstncnnr/go-hyprland/hypr/instance"
+)
+
+var (
+	// ErrNoInstance is returned when Hyprland is not running. A *SocketError for a
+	// socket without a compositor behind it matches it as well.
+	ErrNoInstance = instance.ErrNoInstance
+
+	// ErrMultipleInstances is returned when several instances are running and the
+	// environment does not say which one to use.
+	ErrMultipleInstances = instance.ErrMultipleInstances
+
+	// ErrResponseTooLarge is returned when a reply exceeds the maximum response size.
+	ErrResponseTooLarge = errors.New("hyprland response exceeds maximum size")
+
+	// ErrResponseTruncated is returned when the connection fails after Hyprland
+	// started sending its reply but before it finished. The error of the
+	// connection is wrapped as well.
+	ErrResponseTruncated = errors.New("hyprland response truncated")
+
+	// ErrEmptyRequest is returned when sending a request without commands.
+	ErrEmptyRequest = errors.New("request has no commands")
+
+	// ErrMissingResponse is wrapped by the CommandError of a command Hyprland did
+	// not reply to, which happens when a batch is cut short.
+	ErrMissingResponse = errors.New("no response from hyprland")
+)
+
+// SocketError is returned when the request socket cannot be opened. It wraps the
+// dial error and records the socket path.
+type SocketError = instance.SocketError
+
+// DecodeError is returned when the reply to a query is not valid JSON for the
+// requested type.
+type DecodeError struct {
+	// Command is the query that was sent.
+	Command string
+
+	// Raw is the reply as received from Hyprland.
+	Raw []byte
+
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("error decoding reply to %s: %v", e.Command, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// CommandError is returned for a command of a request that Hyprland did not
+// answer with "ok", or for a query whose reply could not be decoded.
+type CommandError struct {
+	// Index of the command in the request.
+	Index int
+
+	Command commands.Command
+
+	// Response is the reply Hyprland sent for the command.
+	Response string
+
+	// Err is ErrMissingResponse when there was no reply for the command, or a
+	// *DecodeError for a query.
+	Err error
+}
+
+func (e *CommandError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("error running command %d (%s): %v", e.Index, e.Command, e.Err)
+	}
+
+	return fmt.Sprintf("error running command %d (%s): %s", e.Index, e.Command, e.Response)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
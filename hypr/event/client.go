@@ -3,53 +3,213 @@ package events
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net"
-	"os"
-	"strings"
+	"slices"
+	"sync"
 	"time"
+
+	"github.com/jstncnnr/go-hyprland/hypr/instance"
 )
 
 type Listener func(Event)
 
+type registeredListener struct {
+	id       uint64
+	listener Listener
+
+	// stop shuts down the queue of an asynchronous listener.
+	stop func()
+}
+
 type Client struct {
-	connection net.Conn
-	listeners  []Listener
+	socketPath  string
+	maxLineSize int
+	reconnect   *ReconnectPolicy
+	recorder    *Recorder
+
+	listenerDefaults listenerOptions
+	errorHandler     func(error)
+
+	listenersMu    sync.Mutex
+	listeners      []registeredListener
+	nextListenerID uint64
+
+	connectionMu sync.Mutex
+	connection   net.Conn
+	closed       bool
+}
+
+// Option configures a Client created with NewClient.
+type Option func(*Client)
+
+// WithMaxLineSize limits the length of a single event line. Longer lines are
+// reported as a MalformedEvent wrapping ErrLineTooLong and skipped. A size of 0
+// removes the limit. Defaults to DefaultMaxLineSize.
+func WithMaxLineSize(size int) Option {
+	return func(c *Client) {
+		c.maxLineSize = size
+	}
 }
 
-func NewClient() (*Client, error) {
-	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
-	if runtimeDir == "" {
-		runtimeDir = "/tmp"
+// WithInstance listens to the given instance, usually one found with the instance
+// package, instead of the one returned by instance.Current.
+func WithInstance(inst instance.Instance) Option {
+	return func(c *Client) {
+		c.socketPath = inst.EventSocketPath()
+	}
+}
+
+// WithSocketPath uses the given event socket path as is.
+func WithSocketPath(path string) Option {
+	return func(c *Client) {
+		c.socketPath = path
+	}
+}
+
+// NewClient connects to the event socket. Without options the instance is found
+// with instance.Current: HYPRLAND_INSTANCE_SIGNATURE when it is set, otherwise the
+// running instance for WAYLAND_DISPLAY or the only running instance.
+func NewClient(opts ...Option) (*Client, error) {
+	c := &Client{
+		listeners:   make([]registeredListener, 0),
+		maxLineSize: DefaultMaxLineSize,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.socketPath == "" {
+		current, err := instance.Current()
+		if err != nil {
+			return nil, err
+		}
+
+		c.socketPath = current.EventSocketPath()
 	}
 
-	hyprlandInstance := os.Getenv("HYPRLAND_INSTANCE_SIGNATURE")
-	if hyprlandInstance == "" {
-		return nil, errors.New("HYPRLAND_INSTANCE_SIGNATURE environment variable not set. Please ensure Hyprland is running")
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
 	}
 
-	socketPath := fmt.Sprintf("%s/hypr/%s/.socket2.sock", runtimeDir, hyprlandInstance)
-	conn, err := net.Dial("unix", socketPath)
+	c.connection = conn
+	return c, nil
+}
+
+func (c *Client) dial() (net.Conn, error) {
+	conn, err := net.Dial("unix", c.socketPath)
 	if err != nil {
-		return nil, errors.New(fmt.Sprintf("Unable to open hyprland socket: %v", err))
+		return nil, &SocketError{Path: c.socketPath, Err: err}
 	}
 
-	return &Client{
-		connection: conn,
-		listeners:  make([]Listener, 0),
-	}, nil
+	return conn, nil
 }
 
-func (c *Client) RegisterListener(listener Listener) {
-	c.listeners = append(c.listeners, listener)
+// RegisterListener registers a listener for every event read by Listen. The
+// returned Handle removes it again.
+//
+// Listeners may be registered and removed at any time, including from within a
+// listener. Changes take effect with the next event: a listener removed while an
+// event is being dispatched may still receive that event.
+//
+// Listeners are called on the goroutine running Listen unless WithQueue or
+// WithAsyncDispatch gives them a queue of their own.
+func (c *Client) RegisterListener(listener Listener, opts ...ListenerOption) Handle {
+	return Handle{
+		client: c,
+		id:     c.addListener(listener, opts...),
+	}
 }
 
+func (c *Client) addListener(listener Listener, opts ...ListenerOption) uint64 {
+	options := c.listenerDefaults
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	registered := registeredListener{
+		listener: func(event Event) {
+			c.call(listener, event)
+		},
+	}
+
+	if options.async {
+		registered.listener, registered.stop = c.startQueue(listener, options)
+	}
+
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+
+	// The registry is copy-on-write so dispatch can iterate a snapshot without
+	// holding the lock while listeners run.
+	c.nextListenerID++
+	registered.id = c.nextListenerID
+	c.listeners = append(slices.Clip(c.listeners), registered)
+
+	return registered.id
+}
+
+func (c *Client) removeListener(id uint64) {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+
+	c.listeners = slices.DeleteFunc(slices.Clone(c.listeners), func(registered registeredListener) bool {
+		if registered.id != id {
+			return false
+		}
+
+		if registered.stop != nil {
+			registered.stop()
+		}
+
+		return true
+	})
+}
+
+// Listen reads events from the socket and passes them to the registered listeners
+// until ctx is cancelled or the connection fails.
+//
+// When a ReconnectPolicy is configured with WithReconnect, a failed connection is
+// re-dialed instead and listeners receive a DisconnectedEvent and ReconnectedEvent
+// around the outage. Listen only returns once ctx is cancelled, the client is
+// closed or the policy gives up.
 func (c *Client) Listen(ctx context.Context) error {
-	defer func(conn net.Conn) {
-		_ = conn.Close()
-	}(c.connection)
+	defer func() {
+		_ = c.Close()
+	}()
 
-	buffer := make([]byte, 1024)
+	for {
+		conn, err := c.currentConnection()
+		if err != nil {
+			return err
+		}
+
+		err = c.listen(ctx, conn)
+		if c.reconnect == nil || errors.Is(err, context.Canceled) || c.isClosed() {
+			return err
+		}
+
+		c.dispatch(DisconnectedEvent{Error: err})
+		if c.reconnect.OnDisconnect != nil {
+			c.reconnect.OnDisconnect(err)
+		}
+
+		attempts, err := c.redial(ctx)
+		if err != nil {
+			return err
+		}
+
+		c.dispatch(ReconnectedEvent{Attempts: attempts})
+		if c.reconnect.OnReconnect != nil {
+			c.reconnect.OnReconnect(attempts)
+		}
+	}
+}
+
+// listen reads events from a single connection until it fails.
+func (c *Client) listen(ctx context.Context, conn net.Conn) error {
+	reader := newLineReader(conn, c.maxLineSize)
 	for {
 		select {
 		case <-ctx.Done():
@@ -57,38 +217,89 @@ func (c *Client) Listen(ctx context.Context) error {
 		default:
 			// We set a read timeout so we have a chance to check for context cancellation
 			// and shutdown gracefully
-			_ = c.connection.SetReadDeadline(time.Now().Add(time.Second))
+			_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+
+			line, err := reader.ReadLine()
+			if errors.Is(err, ErrLineTooLong) {
+				c.dispatch(MalformedEvent{Raw: line, Error: err})
+				continue
+			}
 
-			read, err := c.connection.Read(buffer)
 			if err != nil {
 				var netErr net.Error
 				if errors.As(err, &netErr) && netErr.Timeout() {
-					// Just continue the loop if we timeout on a read
+					// Just continue the loop if we timeout on a read, the partial
+					// line stays buffered in the reader
 					continue
 				}
 
 				return err
 			}
 
-			events := make([]Event, 0)
-			for _, event := range strings.Split(string(buffer[:read]), "\n") {
-				// The last event has a trailing \n which will give us an empty string to skip
-				if event == "" {
-					continue
-				}
-
-				events = append(events, Parse(event))
+			// Skip empty lines between events
+			if line == "" {
+				continue
 			}
 
-			for _, event := range events {
-				for _, listener := range c.listeners {
-					listener(event)
-				}
+			if c.recorder != nil {
+				// Write errors are kept by the recorder and returned by its Err
+				_ = c.recorder.Record(line)
 			}
+
+			c.dispatch(Parse(line))
 		}
 	}
 }
 
+func (c *Client) dispatch(event Event) {
+	c.listenersMu.Lock()
+	listeners := c.listeners
+	c.listenersMu.Unlock()
+
+	for _, registered := range listeners {
+		registered.listener(event)
+	}
+}
+
+func (c *Client) currentConnection() (net.Conn, error) {
+	c.connectionMu.Lock()
+	defer c.connectionMu.Unlock()
+
+	if c.closed {
+		return nil, net.ErrClosed
+	}
+
+	return c.connection, nil
+}
+
+func (c *Client) isClosed() bool {
+	c.connectionMu.Lock()
+	defer c.connectionMu.Unlock()
+
+	return c.closed
+}
+
+// Close closes the connection and stops Listen, including any reconnect in progress.
+// All listeners are removed. Asynchronous listeners still receive the events
+// already in their queue.
 func (c *Client) Close() error {
+	c.connectionMu.Lock()
+	defer c.connectionMu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	c.closed = true
+
+	c.listenersMu.Lock()
+	for _, registered := range c.listeners {
+		if registered.stop != nil {
+			registered.stop()
+		}
+	}
+	c.listeners = make([]registeredListener, 0)
+	c.listenersMu.Unlock()
+
 	return c.connection.Close()
 }
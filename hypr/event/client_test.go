@@ -0,0 +1,104 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// listenEventSocket creates a temporary instance directory with an event socket
+// and points the environment at it.
+func listenEventSocket(t *testing.T) net.Listener {
+	dir, err := os.MkdirTemp("", "hypr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	if err := os.MkdirAll(filepath.Join(dir, "hypr", "test"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_RUNTIME_DIR", dir)
+	t.Setenv("HYPRLAND_INSTANCE_SIGNATURE", "test")
+
+	listener, err := net.Listen("unix", filepath.Join(dir, "hypr", "test", ".socket2.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	return listener
+}
+
+func TestListenReconnects(t *testing.T) {
+	listener := listenEventSocket(t)
+
+	go func() {
+		// The first connection drops after a single event, the second stays open.
+		for _, line := range []string{"workspace>>1\n", "workspace>>2\n"} {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			_, _ = conn.Write([]byte(line))
+			if line == "workspace>>1\n" {
+				_ = conn.Close()
+			}
+		}
+	}()
+
+	reconnected := 0
+	client, err := NewClient(WithReconnect(ReconnectPolicy{
+		InitialBackoff: time.Millisecond,
+		OnReconnect: func(int) {
+			reconnected++
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	received := make([]Event, 0)
+	client.RegisterListener(func(event Event) {
+		received = append(received, event)
+		if event == (WorkspaceEvent{WorkspaceName: "2"}) {
+			cancel()
+		}
+	})
+
+	_ = client.Listen(ctx)
+
+	if len(received) != 4 {
+		t.Fatalf("expected 4 events, got %d: %v", len(received), received)
+	}
+
+	if _, ok := received[1].(DisconnectedEvent); !ok {
+		t.Errorf("expected DisconnectedEvent, got %T", received[1])
+	}
+
+	if _, ok := received[2].(ReconnectedEvent); !ok {
+		t.Errorf("expected ReconnectedEvent, got %T", received[2])
+	}
+
+	if reconnected != 1 {
+		t.Errorf("expected OnReconnect to be called once, got %d", reconnected)
+	}
+}
+
+func TestNewClientNotRunning(t *testing.T) {
+	_, err := NewClient(WithSocketPath(filepath.Join(t.TempDir(), ".socket2.sock")))
+
+	var socketErr *SocketError
+	if !errors.As(err, &socketErr) || !errors.Is(err, ErrNoInstance) {
+		t.Errorf("expected a SocketError matching ErrNoInstance, got %v", err)
+	}
+}
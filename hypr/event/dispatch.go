@@ -0,0 +1,175 @@
+package events
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+)
+
+// ListenerOption configures how a single listener receives events.
+type ListenerOption func(*listenerOptions)
+
+type listenerOptions struct {
+	async    bool
+	buffer   int
+	overflow OverflowPolicy
+}
+
+// WithQueue gives the listener its own goroutine fed by a queue holding up to
+// buffer events. Events are delivered in order, and a slow listener only delays
+// itself. The overflow policy decides what happens when the queue is full.
+func WithQueue(buffer int, overflow OverflowPolicy) ListenerOption {
+	return func(options *listenerOptions) {
+		options.async = true
+		options.buffer = buffer
+		options.overflow = overflow
+	}
+}
+
+// WithoutQueue calls the listener on the goroutine running Listen, even when
+// WithAsyncDispatch is configured for the client.
+func WithoutQueue() ListenerOption {
+	return func(options *listenerOptions) {
+		options.async = false
+	}
+}
+
+// WithAsyncDispatch gives every listener its own queue, as if it was registered
+// with WithQueue(buffer, overflow). Individual listeners can opt out with
+// WithoutQueue.
+func WithAsyncDispatch(buffer int, overflow OverflowPolicy) Option {
+	return func(c *Client) {
+		WithQueue(buffer, overflow)(&c.listenerDefaults)
+	}
+}
+
+// WithErrorHandler reports panics in listeners to handler as a *PanicError.
+// Panics are always recovered, keeping Listen and other listeners running, and
+// are logged with the log package when no error handler is set.
+func WithErrorHandler(handler func(error)) Option {
+	return func(c *Client) {
+		c.errorHandler = handler
+	}
+}
+
+// PanicError is reported to the error handler when a listener panics.
+type PanicError struct {
+	Event Event
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("listener panicked handling %T: %v", e.Event, e.Value)
+}
+
+// call runs listener, recovering and reporting a panic.
+func (c *Client) call(listener Listener, event Event) {
+	defer func() {
+		value := recover()
+		if value == nil {
+			return
+		}
+
+		err := &PanicError{Event: event, Value: value, Stack: debug.Stack()}
+		if c.errorHandler != nil {
+			c.errorHandler(err)
+		} else {
+			log.Printf("events: %v\n%s", err, err.Stack)
+		}
+	}()
+
+	listener(event)
+}
+
+// startQueue runs listener on its own goroutine. It returns the function that
+// queues events for it and the function that stops it.
+func (c *Client) startQueue(listener Listener, options listenerOptions) (Listener, func()) {
+	done := make(chan struct{})
+	q := newQueue(options.buffer, options.overflow, done)
+
+	go func() {
+		for event := range q.events {
+			c.call(listener, event)
+		}
+	}()
+
+	var once sync.Once
+	return q.send, func() {
+		once.Do(func() {
+			close(done)
+			q.close()
+		})
+	}
+}
+
+// queue is a buffered channel of events with an overflow policy. It backs both
+// subscriptions and asynchronous listeners.
+type queue struct {
+	mu       sync.Mutex
+	events   chan Event
+	overflow OverflowPolicy
+	done     <-chan struct{}
+	closed   bool
+}
+
+// newQueue creates a queue holding up to buffer events. A blocked send gives up
+// once done is closed. The drop policies need room for at least one event, so a
+// buffer of 0 is raised to 1 for them.
+func newQueue(buffer int, overflow OverflowPolicy, done <-chan struct{}) *queue {
+	if overflow != OverflowBlock && buffer < 1 {
+		buffer = 1
+	}
+
+	return &queue{
+		events:   make(chan Event, buffer),
+		overflow: overflow,
+		done:     done,
+	}
+}
+
+func (q *queue) send(event Event) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	switch q.overflow {
+	case OverflowDropNewest:
+		select {
+		case q.events <- event:
+		default:
+		}
+
+	case OverflowDropOldest:
+		for {
+			select {
+			case q.events <- event:
+				return
+			default:
+			}
+
+			select {
+			case <-q.events:
+			default:
+			}
+		}
+
+	default:
+		select {
+		case q.events <- event:
+		case <-q.done:
+		}
+	}
+}
+
+func (q *queue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	close(q.events)
+}
@@ -0,0 +1,101 @@
+package events
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueuedListenerDoesNotBlockOthers(t *testing.T) {
+	client := &Client{}
+
+	release := make(chan struct{})
+	slow := make(chan Event, 2)
+	client.RegisterListener(func(event Event) {
+		<-release
+		slow <- event
+	}, WithQueue(2, OverflowBlock))
+
+	fast := make(chan Event, 2)
+	client.RegisterListener(func(event Event) {
+		fast <- event
+	})
+
+	done := make(chan struct{})
+	go func() {
+		client.dispatch(WorkspaceEvent{WorkspaceName: "1"})
+		client.dispatch(WorkspaceEvent{WorkspaceName: "2"})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("dispatch blocked on the queued listener")
+	}
+
+	close(release)
+	for _, name := range []string{"1", "2"} {
+		if event := <-slow; event != (WorkspaceEvent{WorkspaceName: name}) {
+			t.Errorf("expected workspace %s in order, got %v", name, event)
+		}
+	}
+
+	if len(fast) != 2 {
+		t.Errorf("expected 2 events for the inline listener, got %d", len(fast))
+	}
+}
+
+func TestListenerPanicIsReported(t *testing.T) {
+	reported := make(chan error, 1)
+	client := &Client{}
+	WithErrorHandler(func(err error) {
+		reported <- err
+	})(client)
+
+	client.RegisterListener(func(event Event) {
+		panic("boom")
+	}, WithQueue(1, OverflowBlock))
+
+	client.dispatch(WorkspaceEvent{WorkspaceName: "1"})
+
+	select {
+	case err := <-reported:
+		var panicErr *PanicError
+		if !errors.As(err, &panicErr) || panicErr.Value != "boom" {
+			t.Errorf("expected *PanicError with value boom, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("panic was not reported")
+	}
+}
+
+func TestListenerPanicIsLoggedWithoutHandler(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	client := &Client{}
+	client.RegisterListener(func(event Event) {
+		panic("boom")
+	})
+
+	received := 0
+	client.RegisterListener(func(event Event) {
+		received++
+	})
+
+	client.dispatch(WorkspaceEvent{WorkspaceName: "1"})
+
+	if received != 1 {
+		t.Errorf("expected the other listener to receive the event, got %d calls", received)
+	}
+
+	if !strings.Contains(output.String(), "listener panicked handling events.WorkspaceEvent: boom") {
+		t.Errorf("expected the panic to be logged, got %q", output.String())
+	}
+}
@@ -0,0 +1,242 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Encoder is implemented by every event Hyprland sends on the event socket.
+// Encode returns the line as Hyprland writes it, without the trailing newline,
+// so that Parse(event.Encode()) returns the event again.
+//
+// Only the last argument of an event may contain commas, matching what Parse
+// accepts.
+type Encoder interface {
+	Encode() string
+}
+
+// Encode returns the wire format of event. Events created by the Client, such as
+// DisconnectedEvent, have no wire format and return an error.
+func Encode(event Event) (string, error) {
+	encoder, ok := event.(Encoder)
+	if !ok {
+		return "", fmt.Errorf("event %T has no wire format", event)
+	}
+
+	return encoder.Encode(), nil
+}
+
+func encode(name string, args ...string) string {
+	return name + ">>" + strings.Join(args, ",")
+}
+
+func itoa(value int) string {
+	return strconv.Itoa(value)
+}
+
+func btoa(value bool) string {
+	if value {
+		return "1"
+	}
+
+	return "0"
+}
+
+// Encode returns the raw line the event was parsed from.
+func (e UnhandledEvent) Encode() string {
+	return e.Raw
+}
+
+// Encode returns the raw line the event was parsed from.
+func (e MalformedEvent) Encode() string {
+	return e.Raw
+}
+
+func (e WorkspaceEvent) Encode() string {
+	return encode("workspace", e.WorkspaceName)
+}
+
+func (e WorkspaceV2Event) Encode() string {
+	return encode("workspacev2", itoa(e.WorkspaceID), e.WorkspaceName)
+}
+
+func (e FocusedMonitorEvent) Encode() string {
+	return encode("focusedmon", e.MonitorName, e.WorkspaceName)
+}
+
+func (e FocusedMonitorV2Event) Encode() string {
+	return encode("focusedmonv2", e.MonitorName, itoa(e.WorkspaceID))
+}
+
+func (e ActiveWindowEvent) Encode() string {
+	return encode("activewindow", e.WindowClass, e.WindowTitle)
+}
+
+func (e ActiveWindowV2Event) Encode() string {
+	return encode("activewindowv2", e.WindowAddress)
+}
+
+func (e FullscreenEvent) Encode() string {
+	return encode("fullscreen", itoa(int(e.FullscreenMode)))
+}
+
+func (e MonitorRemovedEvent) Encode() string {
+	return encode("monitorremoved", e.MonitorName)
+}
+
+func (e MonitorRemovedV2Event) Encode() string {
+	return encode("monitorremovedv2", itoa(e.MonitorID), e.MonitorName, e.MonitorDescription)
+}
+
+func (e MonitorAddedEvent) Encode() string {
+	return encode("monitoradded", e.MonitorName)
+}
+
+func (e MonitorAddedV2Event) Encode() string {
+	return encode("monitoraddedv2", itoa(e.MonitorID), e.MonitorName, e.MonitorDescription)
+}
+
+func (e CreateWorkspaceEvent) Encode() string {
+	return encode("createworkspace", e.WorkspaceName)
+}
+
+func (e CreateWorkspaceV2Event) Encode() string {
+	return encode("createworkspacev2", itoa(e.WorkspaceID), e.WorkspaceName)
+}
+
+func (e DestroyWorkspaceEvent) Encode() string {
+	return encode("destroyworkspace", e.WorkspaceName)
+}
+
+func (e DestroyWorkspaceV2Event) Encode() string {
+	return encode("destroyworkspacev2", itoa(e.WorkspaceID), e.WorkspaceName)
+}
+
+func (e MoveWorkspaceEvent) Encode() string {
+	return encode("moveworkspace", e.WorkspaceName, e.MonitorName)
+}
+
+func (e MoveWorkspaceV2Event) Encode() string {
+	return encode("moveworkspacev2", itoa(e.WorkspaceID), e.WorkspaceName, e.MonitorName)
+}
+
+func (e RenameWorkspaceEvent) Encode() string {
+	return encode("renameworkspace", itoa(e.WorkspaceID), e.NewWorkspaceName)
+}
+
+func (e ActiveSpecialEvent) Encode() string {
+	return encode("activespecial", e.WorkspaceName, e.MonitorName)
+}
+
+func (e ActiveSpecialV2Event) Encode() string {
+	// Closing a special workspace sends empty id and name fields
+	id := ""
+	if e.WorkspaceID != 0 || e.WorkspaceName != "" {
+		id = itoa(e.WorkspaceID)
+	}
+
+	return encode("activespecialv2", id, e.WorkspaceName, e.MonitorName)
+}
+
+func (e ActiveLayoutEvent) Encode() string {
+	return encode("activelayout", e.KeyboardName, e.LayoutName)
+}
+
+func (e OpenWindowEvent) Encode() string {
+	return encode("openwindow", e.WindowAddress, e.WorkspaceName, e.WindowClass, e.WindowTitle)
+}
+
+func (e CloseWindowEvent) Encode() string {
+	return encode("closewindow", e.WindowAddress)
+}
+
+func (e MoveWindowEvent) Encode() string {
+	return encode("movewindow", e.WindowAddress, e.WorkspaceName)
+}
+
+func (e MoveWindowV2Event) Encode() string {
+	return encode("movewindowv2", e.WindowAddress, itoa(e.WorkspaceID), e.WorkspaceName)
+}
+
+func (e OpenLayerEvent) Encode() string {
+	return encode("openlayer", e.Namespace)
+}
+
+func (e CloseLayerEvent) Encode() string {
+	return encode("closelayer", e.Namespace)
+}
+
+func (e SubmapEvent) Encode() string {
+	return encode("submap", e.SubmapName)
+}
+
+func (e ChangeFloatingModeEvent) Encode() string {
+	return encode("changefloatingmode", e.WindowAddress, btoa(e.Floating))
+}
+
+func (e UrgentEvent) Encode() string {
+	return encode("urgent", e.WindowAddress)
+}
+
+func (e ScreencastEvent) Encode() string {
+	return encode("screencast", itoa(e.ScreencastState), itoa(int(e.Owner)))
+}
+
+func (e WindowTitleEvent) Encode() string {
+	return encode("windowtitle", e.WindowAddress)
+}
+
+func (e WindowTitleV2Event) Encode() string {
+	return encode("windowtitlev2", e.WindowAddress, e.WindowTitle)
+}
+
+func (e ToggleGroupEvent) Encode() string {
+	return encode("togglegroup", append([]string{itoa(e.GroupState)}, e.WindowAddresses...)...)
+}
+
+func (e MoveIntoGroupEvent) Encode() string {
+	return encode("moveintogroup", e.WindowAddress)
+}
+
+func (e MoveOutOfGroupEvent) Encode() string {
+	return encode("moveoutofgroup", e.WindowAddress)
+}
+
+func (e IgnoreGroupLockEvent) Encode() string {
+	return encode("ignoregrouplock", btoa(e.Ignored))
+}
+
+func (e LockGroupsEvent) Encode() string {
+	return encode("lockgroups", btoa(e.Locked))
+}
+
+func (e ConfigReloadEvent) Encode() string {
+	return encode("configreloaded")
+}
+
+func (e PinEvent) Encode() string {
+	return encode("pin", e.WindowAddress, btoa(e.Pinned))
+}
+
+func (e MinimizedEvent) Encode() string {
+	return encode("minimized", e.WindowAddress, btoa(e.Minimized))
+}
+
+func (e BellEvent) Encode() string {
+	return encode("bell", e.WindowAddress)
+}
+
+func (e CustomEvent) Encode() string {
+	return encode("custom", e.Data)
+}
+
+// Decode unmarshals a JSON payload, as sent by hypr.Request.EmitJSONEvent, into v.
+func (e CustomEvent) Decode(v any) error {
+	if err := json.Unmarshal([]byte(e.Data), v); err != nil {
+		return &DecodeError{Raw: e.Data, Err: err}
+	}
+
+	return nil
+}
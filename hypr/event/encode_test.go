@@ -0,0 +1,54 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+	events := []Event{
+		ToggleGroupEvent{GroupState: 1, WindowAddresses: []string{"62c8246947c0", "62c8246947c1"}},
+		ActiveSpecialV2Event{MonitorName: "DP-1"},
+		UnhandledEvent{Raw: "unknownevent>>a,b"},
+	}
+
+	for _, expected := range eventTests {
+		events = append(events, expected)
+	}
+
+	for _, expected := range commaTests {
+		events = append(events, expected)
+	}
+
+	for _, event := range events {
+		line, err := Encode(event)
+		if err != nil {
+			t.Errorf("Encode(%v): %v", event, err)
+			continue
+		}
+
+		if result := Parse(line); !reflect.DeepEqual(result, event) {
+			t.Errorf("Parse(Encode(%v)): got %v from %q", event, result, line)
+		}
+	}
+}
+
+func TestEncodeMatchesWireFormat(t *testing.T) {
+	for input, event := range eventTests {
+		line, err := Encode(event)
+		if err != nil {
+			t.Errorf("Encode(%v): %v", event, err)
+			continue
+		}
+
+		if line != input {
+			t.Errorf("Encode(%v): expected %q, got %q", event, input, line)
+		}
+	}
+}
+
+func TestEncodeClientEvent(t *testing.T) {
+	if _, err := Encode(ReconnectedEvent{Attempts: 1}); err == nil {
+		t.Errorf("expected an error encoding ReconnectedEvent")
+	}
+}
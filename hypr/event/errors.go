@@ -0,0 +1,38 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/jstncnnr/go-hyprland/hypr/instance"
+)
+
+var (
+	// ErrNoInstance is returned when Hyprland is not running. A *SocketError for a
+	// socket without a compositor behind it matches it as well.
+	ErrNoInstance = instance.ErrNoInstance
+
+	// ErrMultipleInstances is returned when several instances are running and the
+	// environment does not say which one to use.
+	ErrMultipleInstances = instance.ErrMultipleInstances
+)
+
+// SocketError is returned when the event socket cannot be opened. It wraps the
+// dial error and records the socket path.
+type SocketError = instance.SocketError
+
+// DecodeError is returned when a JSON payload cannot be decoded: a tagged event
+// read by UnmarshalEvent, the data of a CustomEvent or a line of a recording.
+type DecodeError struct {
+	// Raw is the payload that failed to decode.
+	Raw string
+
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("error decoding %q: %v", e.Raw, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,36 @@
+package events
+
+// Handle identifies a registered listener so it can be removed again.
+type Handle struct {
+	client *Client
+	id     uint64
+}
+
+// Remove unregisters the listener. Removing a listener more than once is a no-op.
+func (h Handle) Remove() {
+	if h.client != nil {
+		h.client.removeListener(h.id)
+	}
+}
+
+// On registers a handler that is only called for events of type T, for example
+//
+//	events.On(client, func(event events.OpenWindowEvent) {
+//		fmt.Printf("Opened %s\n", event.WindowTitle)
+//	})
+//
+// T may also be an interface, in which case the handler receives every event
+// implementing it. The options are the same as for RegisterListener.
+//
+// Hyprland sends events that have a V2 variant in both versions, so register for
+// only one of them, usually the V2 event since it carries more data.
+func On[T any](c *Client, handler func(T), opts ...ListenerOption) Handle {
+	return Handle{
+		client: c,
+		id: c.addListener(func(event Event) {
+			if typed, ok := event.(T); ok {
+				handler(typed)
+			}
+		}, opts...),
+	}
+}
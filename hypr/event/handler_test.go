@@ -0,0 +1,69 @@
+package events
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestOn(t *testing.T) {
+	client := &Client{}
+
+	received := make([]WorkspaceEvent, 0)
+	handle := On(client, func(event WorkspaceEvent) {
+		received = append(received, event)
+	})
+
+	client.dispatch(WorkspaceEvent{WorkspaceName: "1"})
+	client.dispatch(WorkspaceV2Event{WorkspaceID: 2, WorkspaceName: "2"})
+	handle.Remove()
+	client.dispatch(WorkspaceEvent{WorkspaceName: "3"})
+
+	if len(received) != 1 || received[0].WorkspaceName != "1" {
+		t.Errorf("expected only the first WorkspaceEvent, got %v", received)
+	}
+}
+
+func TestRegisterListenerDuringDispatch(t *testing.T) {
+	client := &Client{}
+
+	calls := 0
+	var handle Handle
+	handle = client.RegisterListener(func(event Event) {
+		calls++
+		handle.Remove()
+
+		// Added during dispatch, so it only sees the next event
+		client.RegisterListener(func(event Event) {
+			calls += 10
+		})
+	})
+
+	client.dispatch(WorkspaceEvent{WorkspaceName: "1"})
+	if calls != 1 {
+		t.Fatalf("expected 1 call after the first event, got %d", calls)
+	}
+
+	client.dispatch(WorkspaceEvent{WorkspaceName: "2"})
+	if calls != 11 {
+		t.Errorf("expected 11 calls after the second event, got %d", calls)
+	}
+}
+
+func TestRegisterListenerConcurrently(t *testing.T) {
+	client := &Client{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client.RegisterListener(func(Event) {}).Remove()
+		}()
+		go func() {
+			defer wg.Done()
+			client.dispatch(WorkspaceEvent{WorkspaceName: "1"})
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,197 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// eventTypes maps the type names used by MarshalEvent to the concrete events.
+// Events read from the socket use their wire name.
+var eventTypes = map[string]Event{
+	"unhandled":          UnhandledEvent{},
+	"malformed":          MalformedEvent{},
+	"disconnected":       DisconnectedEvent{},
+	"reconnected":        ReconnectedEvent{},
+	"workspace":          WorkspaceEvent{},
+	"workspacev2":        WorkspaceV2Event{},
+	"focusedmon":         FocusedMonitorEvent{},
+	"focusedmonv2":       FocusedMonitorV2Event{},
+	"activewindow":       ActiveWindowEvent{},
+	"activewindowv2":     ActiveWindowV2Event{},
+	"fullscreen":         FullscreenEvent{},
+	"monitorremoved":     MonitorRemovedEvent{},
+	"monitorremovedv2":   MonitorRemovedV2Event{},
+	"monitoradded":       MonitorAddedEvent{},
+	"monitoraddedv2":     MonitorAddedV2Event{},
+	"createworkspace":    CreateWorkspaceEvent{},
+	"createworkspacev2":  CreateWorkspaceV2Event{},
+	"destroyworkspace":   DestroyWorkspaceEvent{},
+	"destroyworkspacev2": DestroyWorkspaceV2Event{},
+	"moveworkspace":      MoveWorkspaceEvent{},
+	"moveworkspacev2":    MoveWorkspaceV2Event{},
+	"renameworkspace":    RenameWorkspaceEvent{},
+	"activespecial":      ActiveSpecialEvent{},
+	"activespecialv2":    ActiveSpecialV2Event{},
+	"activelayout":       ActiveLayoutEvent{},
+	"openwindow":         OpenWindowEvent{},
+	"closewindow":        CloseWindowEvent{},
+	"movewindow":         MoveWindowEvent{},
+	"movewindowv2":       MoveWindowV2Event{},
+	"openlayer":          OpenLayerEvent{},
+	"closelayer":         CloseLayerEvent{},
+	"submap":             SubmapEvent{},
+	"changefloatingmode": ChangeFloatingModeEvent{},
+	"urgent":             UrgentEvent{},
+	"screencast":         ScreencastEvent{},
+	"windowtitle":        WindowTitleEvent{},
+	"windowtitlev2":      WindowTitleV2Event{},
+	"togglegroup":        ToggleGroupEvent{},
+	"moveintogroup":      MoveIntoGroupEvent{},
+	"moveoutofgroup":     MoveOutOfGroupEvent{},
+	"ignoregrouplock":    IgnoreGroupLockEvent{},
+	"lockgroups":         LockGroupsEvent{},
+	"configreloaded":     ConfigReloadEvent{},
+	"pin":                PinEvent{},
+	"minimized":          MinimizedEvent{},
+	"bell":               BellEvent{},
+	"custom":             CustomEvent{},
+}
+
+var typeNames = make(map[reflect.Type]string)
+
+func init() {
+	for name, event := range eventTypes {
+		typeNames[reflect.TypeOf(event)] = name
+	}
+}
+
+// taggedEvent is the JSON representation of an event.
+type taggedEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// TypeName returns the name identifying the type of event in its JSON form, for
+// example "openwindow" for an OpenWindowEvent.
+func TypeName(event Event) (string, error) {
+	name, ok := typeNames[reflect.TypeOf(event)]
+	if !ok {
+		return "", fmt.Errorf("unknown event type %T", event)
+	}
+
+	return name, nil
+}
+
+// MarshalEvent encodes event as JSON tagged with its type, for example
+//
+//	{"type": "openwindow", "data": {"windowAddress": "62c8246947c0", ...}}
+//
+// UnmarshalEvent decodes it back into the concrete event.
+func MarshalEvent(event Event) ([]byte, error) {
+	name, err := TypeName(event)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(taggedEvent{Type: name, Data: data})
+}
+
+// UnmarshalEvent decodes an event encoded with MarshalEvent.
+func UnmarshalEvent(data []byte) (Event, error) {
+	var tagged taggedEvent
+	if err := json.Unmarshal(data, &tagged); err != nil {
+		return nil, &DecodeError{Raw: string(data), Err: err}
+	}
+
+	event, ok := eventTypes[tagged.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", tagged.Type)
+	}
+
+	value := reflect.New(reflect.TypeOf(event))
+	if len(tagged.Data) > 0 {
+		if err := json.Unmarshal(tagged.Data, value.Interface()); err != nil {
+			return nil, &DecodeError{Raw: string(data), Err: fmt.Errorf("%s event: %w", tagged.Type, err)}
+		}
+	}
+
+	return value.Elem().Interface(), nil
+}
+
+// JSONEvent wraps an Event so it can be embedded in other JSON documents using
+// the tagged form of MarshalEvent.
+type JSONEvent struct {
+	Event Event
+}
+
+func (e JSONEvent) MarshalJSON() ([]byte, error) {
+	return MarshalEvent(e.Event)
+}
+
+func (e *JSONEvent) UnmarshalJSON(data []byte) error {
+	event, err := UnmarshalEvent(data)
+	if err != nil {
+		return err
+	}
+
+	e.Event = event
+	return nil
+}
+
+// errorJSON is the JSON form of events carrying an error, which is kept as its message.
+type errorJSON struct {
+	Raw   string `json:"raw,omitempty"`
+	Error string `json:"error"`
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+func messageError(message string) error {
+	if message == "" {
+		return nil
+	}
+
+	return errors.New(message)
+}
+
+func (e MalformedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(errorJSON{Raw: e.Raw, Error: errorMessage(e.Error)})
+}
+
+func (e *MalformedEvent) UnmarshalJSON(data []byte) error {
+	var decoded errorJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	e.Raw = decoded.Raw
+	e.Error = messageError(decoded.Error)
+	return nil
+}
+
+func (e DisconnectedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(errorJSON{Error: errorMessage(e.Error)})
+}
+
+func (e *DisconnectedEvent) UnmarshalJSON(data []byte) error {
+	var decoded errorJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	e.Error = messageError(decoded.Error)
+	return nil
+}
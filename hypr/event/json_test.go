@@ -0,0 +1,77 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshalEventRoundTrip(t *testing.T) {
+	events := []Event{
+		UnhandledEvent{Raw: "unhandled>>"},
+		ReconnectedEvent{Attempts: 3},
+		ToggleGroupEvent{GroupState: 1, WindowAddresses: []string{"62c8246947c0", "62c8246947c1"}},
+	}
+
+	for _, expected := range eventTests {
+		events = append(events, expected)
+	}
+
+	for _, event := range events {
+		data, err := MarshalEvent(event)
+		if err != nil {
+			t.Errorf("MarshalEvent(%v): %v", event, err)
+			continue
+		}
+
+		result, err := UnmarshalEvent(data)
+		if err != nil {
+			t.Errorf("UnmarshalEvent(%s): %v", data, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(result, event) {
+			t.Errorf("UnmarshalEvent(MarshalEvent(%v)): got %v from %s", event, result, data)
+		}
+	}
+}
+
+func TestMarshalEventFormat(t *testing.T) {
+	data, err := MarshalEvent(WorkspaceV2Event{WorkspaceID: 1, WorkspaceName: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"type":"workspacev2","data":{"workspaceID":1,"workspaceName":"test"}}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
+
+func TestMarshalMalformedEvent(t *testing.T) {
+	data, err := json.Marshal([]JSONEvent{{Event: MalformedEvent{Raw: "workspacev2>>test", Error: errors.New("bad id")}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded []JSONEvent
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	event, ok := decoded[0].Event.(MalformedEvent)
+	if !ok || event.Raw != "workspacev2>>test" || event.Error == nil || event.Error.Error() != "bad id" {
+		t.Errorf("did not decode MalformedEvent from %s, got %v", data, decoded[0].Event)
+	}
+}
+
+func TestUnmarshalEventDecodeError(t *testing.T) {
+	_, err := UnmarshalEvent([]byte(`{"type":"workspacev2","data":{"workspaceID":"one"}}`))
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || !strings.Contains(decodeErr.Raw, `"one"`) {
+		t.Errorf("expected a DecodeError with the raw payload, got %v", err)
+	}
+}
@@ -1,81 +1,109 @@
 package events
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 )
 
-var parsers = map[string]func([]string) (Event, error){
-	"workspace":          parseWorkspaceEvent,
-	"workspacev2":        parseWorkspaceV2Event,
-	"focusedmon":         parseFocusedMonEvent,
-	"focusedmonv2":       parseFocusedMonV2Event,
-	"activewindow":       parseActiveWindowEvent,
-	"activewindowv2":     parseActiveWindowV2Event,
-	"fullscreen":         parseFullscreenEvent,
-	"monitorremoved":     parseMonitorRemovedEvent,
-	"monitorremovedv2":   parseMOnitorRemovedV2Event,
-	"monitoradded":       parseMonitorAddedEvent,
-	"monitoraddedv2":     parseMonitorAddedV2Event,
-	"createworkspace":    parseCreateWorkspaceEvent,
-	"createworkspacev2":  parseCreateWorkspaceV2Event,
-	"destroyworkspace":   parseDestroyWorkspaceEvent,
-	"destroyworkspacev2": parseDestroyWorkspaceV2Event,
-	"moveworkspace":      parseMoveWorkspaceEvent,
-	"moveworkspacev2":    parseMoveWorkspaceV2Event,
-	"renameworkspace":    parseRenameWorkspaceEvent,
-	"activespecial":      parseActiveSpecialEvent,
-	"activespecialv2":    parseActiveSpecialV2Event,
-	"activelayout":       parseActiveLayoutEvent,
-	"openwindow":         parseOpenWindowEvent,
-	"closewindow":        parseCloseWindowEvent,
-	"movewindow":         parseMoveWindowEvent,
-	"movewindowv2":       parseMoveWindowV2Event,
-	"openlayer":          parseOpenLayerEvent,
-	"closelayer":         parseCloseLayerEvent,
-	"submap":             parseSubmapEvent,
-	"changefloatingmode": parseChangeFloatingModeEvent,
-	"urgent":             parseUrgentEvent,
-	"screencast":         parseScreencastEvent,
-	"windowtitle":        parseWindowTitleEvent,
-	"windowtitlev2":      parseWindowTitleV2Event,
-	"togglegroup":        parseToggleGroupEvent,
-	"moveintogroup":      parseMoveIntoGroupEvent,
-	"moveoutofgroup":     parseMoveOutOfGroupEvent,
-	"ignoregrouplock":    parseIgnoreGroupLockEvent,
-	"lockgroups":         parseLockGroupsEvent,
-	"configreloaded":     parseConfigReloadedEvent,
-	"pin":                parsePinEvent,
-	"minimized":          parseMinimizedEvent,
-	"bell":               parseBellEvent,
+// parser decodes the payload of one event type.
+type parser struct {
+	// fields is the number of comma separated fields in the payload. The last
+	// field takes the remainder of the payload, so it may contain commas. -1
+	// splits on every comma.
+	fields int
+	parse  func([]string) (Event, error)
+}
+
+var parsers = map[string]parser{
+	"workspace":          {1, parseWorkspaceEvent},
+	"workspacev2":        {2, parseWorkspaceV2Event},
+	"focusedmon":         {2, parseFocusedMonEvent},
+	"focusedmonv2":       {2, parseFocusedMonV2Event},
+	"activewindow":       {2, parseActiveWindowEvent},
+	"activewindowv2":     {1, parseActiveWindowV2Event},
+	"fullscreen":         {1, parseFullscreenEvent},
+	"monitorremoved":     {1, parseMonitorRemovedEvent},
+	"monitorremovedv2":   {3, parseMOnitorRemovedV2Event},
+	"monitoradded":       {1, parseMonitorAddedEvent},
+	"monitoraddedv2":     {3, parseMonitorAddedV2Event},
+	"createworkspace":    {1, parseCreateWorkspaceEvent},
+	"createworkspacev2":  {2, parseCreateWorkspaceV2Event},
+	"destroyworkspace":   {1, parseDestroyWorkspaceEvent},
+	"destroyworkspacev2": {2, parseDestroyWorkspaceV2Event},
+	"moveworkspace":      {2, parseMoveWorkspaceEvent},
+	"moveworkspacev2":    {3, parseMoveWorkspaceV2Event},
+	"renameworkspace":    {2, parseRenameWorkspaceEvent},
+	"activespecial":      {2, parseActiveSpecialEvent},
+	"activespecialv2":    {3, parseActiveSpecialV2Event},
+	"activelayout":       {2, parseActiveLayoutEvent},
+	"openwindow":         {4, parseOpenWindowEvent},
+	"closewindow":        {1, parseCloseWindowEvent},
+	"movewindow":         {2, parseMoveWindowEvent},
+	"movewindowv2":       {3, parseMoveWindowV2Event},
+	"openlayer":          {1, parseOpenLayerEvent},
+	"closelayer":         {1, parseCloseLayerEvent},
+	"submap":             {1, parseSubmapEvent},
+	"changefloatingmode": {2, parseChangeFloatingModeEvent},
+	"urgent":             {1, parseUrgentEvent},
+	"screencast":         {2, parseScreencastEvent},
+	"windowtitle":        {1, parseWindowTitleEvent},
+	"windowtitlev2":      {2, parseWindowTitleV2Event},
+	"togglegroup":        {-1, parseToggleGroupEvent},
+	"moveintogroup":      {1, parseMoveIntoGroupEvent},
+	"moveoutofgroup":     {1, parseMoveOutOfGroupEvent},
+	"ignoregrouplock":    {1, parseIgnoreGroupLockEvent},
+	"lockgroups":         {1, parseLockGroupsEvent},
+	"configreloaded":     {0, parseConfigReloadedEvent},
+	"pin":                {2, parsePinEvent},
+	"minimized":          {2, parseMinimizedEvent},
+	"bell":               {1, parseBellEvent},
+	"custom":             {1, parseCustomEvent},
 }
 
 // Parse will take the raw event string in the format
 // {type}>>{arg0},{arg1},...{argN} and return an Event.
 //
+// Every event type has a fixed number of arguments and the last one takes the
+// rest of the line, so window titles and other free text may contain commas.
+//
 // Returns UnhandledEvent when the event type is unknown.
 // This includes the raw event string for the user to handle.
 //
-// Returns MalformedEvent when there is an error parsing the event.
+// Returns MalformedEvent when there is an error parsing the event, including
+// lines without a ">>" separator or with too few arguments.
 // This includes the raw event string and error message for the user.
 func Parse(raw string) Event {
-	parts := strings.Split(raw, ">>")
-	eventName := parts[0]
-
-	parserFunc, ok := parsers[eventName]
-	if ok {
-		event, err := parserFunc(strings.Split(parts[1], ","))
-		if err != nil {
-			return MalformedEvent{Raw: raw, Error: err}
+	eventName, payload, found := strings.Cut(raw, ">>")
+	if !found {
+		return MalformedEvent{Raw: raw, Error: errors.New("missing \">>\" separator")}
+	}
+
+	p, ok := parsers[eventName]
+	if !ok {
+		return UnhandledEvent{
+			Raw: raw,
 		}
+	}
 
-		return event
+	var args []string
+	switch {
+	case p.fields < 0:
+		args = strings.Split(payload, ",")
+	case p.fields > 0:
+		args = strings.SplitN(payload, ",", p.fields)
+		if len(args) < p.fields {
+			return MalformedEvent{Raw: raw, Error: fmt.Errorf("expected %d arguments, got %d", p.fields, len(args))}
+		}
 	}
 
-	return UnhandledEvent{
-		Raw: raw,
+	event, err := p.parse(args)
+	if err != nil {
+		return MalformedEvent{Raw: raw, Error: err}
 	}
+
+	return event
 }
 
 func parseWorkspaceEvent(args []string) (Event, error) {
@@ -453,3 +481,9 @@ func parseBellEvent(args []string) (Event, error) {
 		WindowAddress: args[0],
 	}, nil
 }
+
+func parseCustomEvent(args []string) (Event, error) {
+	return CustomEvent{
+		Data: args[0],
+	}, nil
+}
@@ -19,6 +19,8 @@ var eventTests = map[string]Event{
 	"monitoraddedv2>>1,DP-1,Description":        MonitorAddedV2Event{MonitorID: 1, MonitorName: "DP-1", MonitorDescription: "Description"},
 	"createworkspace>>test":                     CreateWorkspaceEvent{WorkspaceName: "test"},
 	"createworkspacev2>>1,test":                 CreateWorkspaceV2Event{WorkspaceID: 1, WorkspaceName: "test"},
+	"destroyworkspace>>test":                    DestroyWorkspaceEvent{WorkspaceName: "test"},
+	"destroyworkspacev2>>1,test":                DestroyWorkspaceV2Event{WorkspaceID: 1, WorkspaceName: "test"},
 	"moveworkspace>>test,DP-1":                  MoveWorkspaceEvent{WorkspaceName: "test", MonitorName: "DP-1"},
 	"moveworkspacev2>>1,test,DP-1":              MoveWorkspaceV2Event{WorkspaceID: 1, WorkspaceName: "test", MonitorName: "DP-1"},
 	"renameworkspace>>1,test":                   RenameWorkspaceEvent{WorkspaceID: 1, NewWorkspaceName: "test"},
@@ -46,6 +48,15 @@ var eventTests = map[string]Event{
 	"minimized>>62c8246947c0,0":                 MinimizedEvent{WindowAddress: "62c8246947c0", Minimized: false},
 	"bell>>62c8246947c0":                        BellEvent{WindowAddress: "62c8246947c0"},
 	"bell>>":                                    BellEvent{WindowAddress: ""},
+	"custom>>reload,theme=dark":                 CustomEvent{Data: "reload,theme=dark"},
+}
+
+var commaTests = map[string]Event{
+	"activewindow>>firefox,Search, Maps, Mail":              ActiveWindowEvent{WindowClass: "firefox", WindowTitle: "Search, Maps, Mail"},
+	"openwindow>>62c8246947c0,1,firefox,Search, Maps, Mail": OpenWindowEvent{WindowAddress: "62c8246947c0", WorkspaceName: "1", WindowClass: "firefox", WindowTitle: "Search, Maps, Mail"},
+	"windowtitlev2>>62c8246947c0,Search, Maps, Mail":        WindowTitleV2Event{WindowAddress: "62c8246947c0", WindowTitle: "Search, Maps, Mail"},
+	"activewindow>>,":      ActiveWindowEvent{},
+	"submap>>resize>>mode": SubmapEvent{SubmapName: "resize>>mode"},
 }
 
 func TestParseWithValidInput(t *testing.T) {
@@ -58,6 +69,25 @@ func TestParseWithValidInput(t *testing.T) {
 	}
 }
 
+func TestParseWithCommasInLastArgument(t *testing.T) {
+	for input, expected := range commaTests {
+		result := Parse(input)
+
+		if result != expected {
+			t.Errorf("Parse(%q): expected %v, got %v", input, expected, result)
+		}
+	}
+}
+
+func TestParseWithTooFewArguments(t *testing.T) {
+	for _, input := range []string{"openwindow>>62c8246947c0,1", "workspacev2>>1", "pin>>62c8246947c0", "activewindow"} {
+		result := Parse(input)
+		if reflect.TypeOf(result) != reflect.TypeOf(MalformedEvent{}) {
+			t.Errorf("Parse(%q): did not receive MalformedEvent, got %v", input, result)
+		}
+	}
+}
+
 func TestParseToggleGroupEvent(t *testing.T) {
 	result := Parse("togglegroup>>0,62c8246947c0,62c8246947c1")
 
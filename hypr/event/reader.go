@@ -0,0 +1,94 @@
+package events
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+// DefaultMaxLineSize is the longest event line the Client accepts unless
+// configured otherwise with WithMaxLineSize.
+const DefaultMaxLineSize = 1 << 20
+
+// ErrLineTooLong is reported when an event line exceeds the maximum line size.
+// The rest of the line is discarded and reading continues with the next line.
+var ErrLineTooLong = errors.New("event line exceeds maximum size")
+
+// lineReader splits the event socket stream into lines. Partial lines are kept
+// across reads, including reads that fail with a timeout, so an event is never
+// split in two regardless of how the kernel delivers it.
+type lineReader struct {
+	reader     io.Reader
+	maxSize    int
+	buffer     []byte
+	start      int
+	discarding bool
+}
+
+func newLineReader(reader io.Reader, maxSize int) *lineReader {
+	return &lineReader{
+		reader:  reader,
+		maxSize: maxSize,
+		buffer:  make([]byte, 0, 4096),
+	}
+}
+
+// ReadLine returns the next line without its trailing newline. A maxSize of 0
+// allows lines of any length.
+//
+// When a line is longer than maxSize, the first maxSize bytes are returned along
+// with ErrLineTooLong. Any other error comes from the underlying reader.
+func (r *lineReader) ReadLine() (string, error) {
+	for {
+		pending := r.buffer[r.start:]
+		if index := bytes.IndexByte(pending, '\n'); index >= 0 {
+			r.start += index + 1
+			if r.discarding {
+				r.discarding = false
+				continue
+			}
+
+			if r.maxSize > 0 && index > r.maxSize {
+				return string(pending[:r.maxSize]), ErrLineTooLong
+			}
+
+			return string(pending[:index]), nil
+		}
+
+		if r.discarding {
+			r.start = len(r.buffer)
+		} else if r.maxSize > 0 && len(pending) > r.maxSize {
+			r.discarding = true
+			r.start = len(r.buffer)
+			return string(pending[:r.maxSize]), ErrLineTooLong
+		}
+
+		if err := r.fill(); err != nil {
+			return "", err
+		}
+	}
+}
+
+// fill moves any partial line to the front of the buffer and reads more data
+// behind it.
+func (r *lineReader) fill() error {
+	if r.start > 0 {
+		r.buffer = r.buffer[:copy(r.buffer, r.buffer[r.start:])]
+		r.start = 0
+	}
+
+	if len(r.buffer) == cap(r.buffer) {
+		r.buffer = append(r.buffer, make([]byte, cap(r.buffer))...)[:len(r.buffer)]
+	}
+
+	read, err := r.reader.Read(r.buffer[len(r.buffer):cap(r.buffer)])
+	r.buffer = r.buffer[:len(r.buffer)+read]
+
+	// Data that arrived alongside an error is processed first, the error will be
+	// reported again by the next read.
+	if read > 0 {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,76 @@
+package events
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestLineReaderJoinsSplitReads(t *testing.T) {
+	title := strings.Repeat("a very long window title ", 200)
+	input := "openwindow>>62c8246947c0,1,class," + title + "\nworkspace>>1\n"
+
+	reader := newLineReader(iotest.OneByteReader(strings.NewReader(input)), DefaultMaxLineSize)
+
+	expected := []string{"openwindow>>62c8246947c0,1,class," + title, "workspace>>1"}
+	for _, want := range expected {
+		line, err := reader.ReadLine()
+		if err != nil {
+			t.Fatalf("ReadLine: %v", err)
+		}
+
+		if line != want {
+			t.Errorf("expected %q, got %q", want, line)
+		}
+	}
+
+	if _, err := reader.ReadLine(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestLineReaderKeepsPartialLineAcrossErrors(t *testing.T) {
+	reader := newLineReader(iotest.TimeoutReader(strings.NewReader("workspace>>1\n")), DefaultMaxLineSize)
+
+	// Limit the first read to part of the line so the timeout hits mid-line.
+	reader.buffer = make([]byte, 0, 4)
+
+	if _, err := reader.ReadLine(); !errors.Is(err, iotest.ErrTimeout) {
+		t.Fatalf("expected iotest.ErrTimeout, got %v", err)
+	}
+
+	reader.reader = strings.NewReader("\n")
+	line, err := reader.ReadLine()
+	if err != nil {
+		t.Fatalf("ReadLine: %v", err)
+	}
+
+	if line != "work" {
+		t.Errorf("expected %q, got %q", "work", line)
+	}
+}
+
+func TestLineReaderLineTooLong(t *testing.T) {
+	input := strings.Repeat("x", 100) + "\nworkspace>>1\n"
+	reader := newLineReader(iotest.OneByteReader(strings.NewReader(input)), 20)
+
+	line, err := reader.ReadLine()
+	if !errors.Is(err, ErrLineTooLong) {
+		t.Fatalf("expected ErrLineTooLong, got %v", err)
+	}
+
+	if line != strings.Repeat("x", 20) {
+		t.Errorf("expected truncated line, got %q", line)
+	}
+
+	line, err = reader.ReadLine()
+	if err != nil {
+		t.Fatalf("ReadLine: %v", err)
+	}
+
+	if line != "workspace>>1" {
+		t.Errorf("expected %q, got %q", "workspace>>1", line)
+	}
+}
@@ -0,0 +1,94 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// ReconnectPolicy controls how Listen re-dials the event socket after the
+// connection drops, for example when Hyprland restarts its IPC.
+type ReconnectPolicy struct {
+	// InitialBackoff is the delay before the first attempt. Defaults to 100ms.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between attempts. Defaults to 30s.
+	MaxBackoff time.Duration
+
+	// Multiplier grows the delay after every failed attempt. Defaults to 2.
+	Multiplier float64
+
+	// MaxAttempts is the number of consecutive failed attempts before Listen gives
+	// up and returns the last error. 0 retries forever.
+	MaxAttempts int
+
+	// OnDisconnect is called when the connection is lost, before reconnecting.
+	OnDisconnect func(err error)
+
+	// OnReconnect is called once the connection is re-established with the number
+	// of attempts it took.
+	OnReconnect func(attempts int)
+}
+
+// WithReconnect makes Listen re-dial the event socket using policy instead of
+// returning when the connection fails. Registered listeners are kept.
+func WithReconnect(policy ReconnectPolicy) Option {
+	return func(c *Client) {
+		if policy.InitialBackoff <= 0 {
+			policy.InitialBackoff = 100 * time.Millisecond
+		}
+
+		if policy.MaxBackoff <= 0 {
+			policy.MaxBackoff = 30 * time.Second
+		}
+
+		if policy.Multiplier < 1 {
+			policy.Multiplier = 2
+		}
+
+		c.reconnect = &policy
+	}
+}
+
+// redial waits out the backoff and dials the socket until it succeeds, ctx is
+// cancelled, the client is closed or the policy runs out of attempts.
+func (c *Client) redial(ctx context.Context) (int, error) {
+	backoff := c.reconnect.InitialBackoff
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return attempt, context.Canceled
+		case <-timer.C:
+		}
+
+		conn, err := c.dial()
+		if err == nil {
+			c.connectionMu.Lock()
+			defer c.connectionMu.Unlock()
+
+			if c.closed {
+				_ = conn.Close()
+				return attempt, net.ErrClosed
+			}
+
+			_ = c.connection.Close()
+			c.connection = conn
+			return attempt, nil
+		}
+
+		if c.reconnect.MaxAttempts > 0 && attempt >= c.reconnect.MaxAttempts {
+			return attempt, fmt.Errorf("giving up after %d reconnect attempts: %w", attempt, err)
+		}
+
+		if c.isClosed() {
+			return attempt, net.ErrClosed
+		}
+
+		backoff = min(time.Duration(float64(backoff)*c.reconnect.Multiplier), c.reconnect.MaxBackoff)
+		timer.Reset(backoff)
+	}
+}
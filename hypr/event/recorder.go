@@ -0,0 +1,103 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// Record is a single line of a recording. Recordings are stored as NDJSON, one
+// Record per line:
+//
+//	{"offset":1500000000,"line":"workspace>>2"}
+type Record struct {
+	// Offset is the time since the recording started, measured with the monotonic
+	// clock. It is encoded in nanoseconds.
+	Offset time.Duration `json:"offset"`
+
+	// Line is the raw event line without the trailing newline.
+	Line string `json:"line"`
+}
+
+// Recorder writes raw event lines with their offsets to a writer. It is safe for
+// concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	start   time.Time
+	err     error
+}
+
+// NewRecorder starts a recording to w. Offsets are measured from the moment it
+// is created.
+func NewRecorder(w io.Writer) *Recorder {
+	// Event lines are full of ">>", which would otherwise be escaped.
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	return &Recorder{
+		encoder: encoder,
+		start:   time.Now(),
+	}
+}
+
+// WithRecorder records every line read from the event socket, including lines
+// that fail to parse, before they are dispatched.
+func WithRecorder(recorder *Recorder) Option {
+	return func(c *Client) {
+		c.recorder = recorder
+	}
+}
+
+// Record writes line with the current offset. After the first write error every
+// call returns that error without writing.
+func (r *Recorder) Record(line string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+
+	r.err = r.encoder.Encode(Record{Offset: time.Since(r.start), Line: line})
+	return r.err
+}
+
+// Err returns the first error encountered while writing.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.err
+}
+
+// ReadRecording reads every Record of an NDJSON recording. Blank lines are
+// skipped and lines may be of any length.
+func ReadRecording(r io.Reader) ([]Record, error) {
+	records := make([]Record, 0)
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var record Record
+			if err := json.Unmarshal(line, &record); err != nil {
+				return nil, &DecodeError{Raw: string(line), Err: err}
+			}
+
+			records = append(records, record)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ErrReplayStarted is returned when a Replayer is run a second time.
+var ErrReplayStarted = errors.New("replay already started")
+
+// ReplayOption configures a Replayer created with NewReplayer.
+type ReplayOption func(*Replayer)
+
+// WithSpeed replays faster or slower than real time: 2 halves the delays between
+// records and 0.5 doubles them. Factors of 0 or less are ignored.
+func WithSpeed(factor float64) ReplayOption {
+	return func(r *Replayer) {
+		if factor > 0 {
+			r.speed = factor
+		}
+	}
+}
+
+// WithoutDelay replays every record immediately, ignoring the offsets.
+func WithoutDelay() ReplayOption {
+	return func(r *Replayer) {
+		r.speed = 0
+	}
+}
+
+// WithStepping replays one record per call to Replayer.Step, ignoring the
+// offsets.
+func WithStepping() ReplayOption {
+	return func(r *Replayer) {
+		r.stepping = true
+	}
+}
+
+// Replayer plays back a recording made with a Recorder. By default records are
+// replayed in real time, keeping the delays between them. A Replayer can only be
+// run once.
+type Replayer struct {
+	records  []Record
+	speed    float64
+	stepping bool
+
+	started atomic.Bool
+	step    chan struct{}
+	stepped chan struct{}
+	done    chan struct{}
+}
+
+// NewReplayer creates a Replayer for records, usually read with ReadRecording.
+func NewReplayer(records []Record, opts ...ReplayOption) *Replayer {
+	r := &Replayer{
+		records: records,
+		speed:   1,
+		step:    make(chan struct{}),
+		stepped: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Run passes the raw line of every record to emit, in order and at the pace set
+// by the options. It returns once all records are replayed or ctx is cancelled.
+func (r *Replayer) Run(ctx context.Context, emit func(line string)) error {
+	if r.started.Swap(true) {
+		return ErrReplayStarted
+	}
+	defer close(r.done)
+
+	start := time.Now()
+	for _, record := range r.records {
+		if err := r.wait(ctx, start, record); err != nil {
+			return err
+		}
+
+		emit(record.Line)
+
+		if r.stepping {
+			// Step returns once the record has been handled
+			r.stepped <- struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// Play parses every record with Parse and passes the events to listener.
+func (r *Replayer) Play(ctx context.Context, listener Listener) error {
+	return r.Run(ctx, func(line string) {
+		listener(Parse(line))
+	})
+}
+
+// Step replays the next record when stepping and waits until it has been handled.
+// It reports false once the replay has finished.
+func (r *Replayer) Step() bool {
+	select {
+	case r.step <- struct{}{}:
+	case <-r.done:
+		return false
+	}
+
+	<-r.stepped
+	return true
+}
+
+// wait blocks until record is due.
+func (r *Replayer) wait(ctx context.Context, start time.Time, record Record) error {
+	switch {
+	case r.stepping:
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-r.step:
+			return nil
+		}
+
+	case r.speed == 0:
+		return ctx.Err()
+	}
+
+	delay := time.Until(start.Add(time.Duration(float64(record.Offset) / r.speed)))
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Replay plays back r through Parse to the registered listeners, as if the events
+// had been read from the socket.
+func (c *Client) Replay(ctx context.Context, r *Replayer) error {
+	return r.Play(ctx, c.dispatch)
+}
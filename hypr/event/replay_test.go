@@ -0,0 +1,168 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordListen(t *testing.T) {
+	listener := listenEventSocket(t)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		_, _ = conn.Write([]byte("workspace>>2\n\nbogus\nactivewindow>>kitty,~\n"))
+	}()
+
+	var recording bytes.Buffer
+	client, err := NewClient(WithRecorder(NewRecorder(&recording)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client.RegisterListener(func(event Event) {
+		if _, ok := event.(ActiveWindowEvent); ok {
+			cancel()
+		}
+	})
+	_ = client.Listen(ctx)
+
+	records, err := ReadRecording(&recording)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := make([]string, 0, len(records))
+	for i, record := range records {
+		lines = append(lines, record.Line)
+		if i > 0 && record.Offset < records[i-1].Offset {
+			t.Errorf("offsets are not monotonic: %v", records)
+		}
+	}
+
+	expected := []string{"workspace>>2", "bogus", "activewindow>>kitty,~"}
+	if len(lines) != len(expected) || lines[0] != expected[0] || lines[1] != expected[1] || lines[2] != expected[2] {
+		t.Errorf("expected %q, got %q", expected, lines)
+	}
+}
+
+func TestRecordingRoundTrip(t *testing.T) {
+	lines := []string{
+		"workspace>>2",
+		"activewindow>>kitty," + strings.Repeat("<&>", DefaultMaxLineSize/3),
+	}
+
+	var recording bytes.Buffer
+	recorder := NewRecorder(&recording)
+	for _, line := range lines {
+		if err := recorder.Record(line); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !strings.HasPrefix(recording.String(), `{"offset":`) || !strings.Contains(recording.String(), `"line":"workspace>>2"}`) {
+		t.Errorf("expected lines to be written unescaped, got %.100q", recording.String())
+	}
+
+	records, err := ReadRecording(&recording)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != len(lines) || records[0].Line != lines[0] || records[1].Line != lines[1] {
+		t.Errorf("expected the recorded lines back, got %d records", len(records))
+	}
+}
+
+func TestReadRecordingDecodeError(t *testing.T) {
+	_, err := ReadRecording(bytes.NewBufferString("{\"offset\":0,\"line\":\"workspace>>1\"}\n\nnot json\n"))
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.Raw != "not json" {
+		t.Errorf("expected a DecodeError for the bad line, got %v", err)
+	}
+}
+
+func TestReplaySpeed(t *testing.T) {
+	records := []Record{
+		{Offset: 0, Line: "workspace>>1"},
+		{Offset: 200 * time.Millisecond, Line: "workspace>>2"},
+	}
+
+	start := time.Now()
+	received := make([]Event, 0)
+	err := NewReplayer(records, WithSpeed(4)).Play(context.Background(), func(event Event) {
+		received = append(received, event)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > time.Second {
+		t.Errorf("expected the replay to take about 50ms, took %v", elapsed)
+	}
+
+	if len(received) != 2 || received[1] != (WorkspaceEvent{WorkspaceName: "2"}) {
+		t.Errorf("unexpected events %v", received)
+	}
+}
+
+func TestReplayCancel(t *testing.T) {
+	replayer := NewReplayer([]Record{{Offset: time.Hour, Line: "workspace>>1"}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := replayer.Run(ctx, func(string) {}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline error, got %v", err)
+	}
+
+	if err := replayer.Run(context.Background(), func(string) {}); !errors.Is(err, ErrReplayStarted) {
+		t.Errorf("expected ErrReplayStarted, got %v", err)
+	}
+}
+
+func TestReplayStepping(t *testing.T) {
+	records := []Record{
+		{Offset: time.Hour, Line: "workspace>>1"},
+		{Offset: 2 * time.Hour, Line: "workspace>>2"},
+	}
+	replayer := NewReplayer(records, WithStepping())
+
+	lines := make(chan string, len(records))
+	go func() {
+		_ = replayer.Run(context.Background(), func(line string) {
+			lines <- line
+		})
+	}()
+
+	for _, record := range records {
+		if !replayer.Step() {
+			t.Fatal("expected another record")
+		}
+
+		// Step returns after the record was handled
+		select {
+		case line := <-lines:
+			if line != record.Line {
+				t.Errorf("expected %q, got %q", record.Line, line)
+			}
+		default:
+			t.Fatalf("expected %q to be handled", record.Line)
+		}
+	}
+
+	if replayer.Step() {
+		t.Error("expected Step to report the end of the replay")
+	}
+}
@@ -0,0 +1,44 @@
+package events
+
+import "context"
+
+// OverflowPolicy decides what happens to an event when the buffer of a subscription
+// or a queued listener is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for the consumer to make room. This stalls the delivery
+	// of events to every other listener until it does.
+	OverflowBlock OverflowPolicy = 0
+
+	// OverflowDropOldest discards the oldest buffered event to make room.
+	OverflowDropOldest OverflowPolicy = 1
+
+	// OverflowDropNewest discards the event that did not fit.
+	OverflowDropNewest OverflowPolicy = 2
+)
+
+// SubscribeOptions configures a subscription created with Client.Subscribe.
+type SubscribeOptions struct {
+	// Buffer is the capacity of the returned channel. The drop policies need room
+	// for at least one event, so a Buffer of 0 is raised to 1 for them.
+	Buffer int
+
+	// Overflow decides what happens when the buffer is full.
+	Overflow OverflowPolicy
+}
+
+// Subscribe returns a channel receiving every event read by Listen. The
+// subscription ends and the channel is closed once ctx is done.
+func (c *Client) Subscribe(ctx context.Context, opts SubscribeOptions) <-chan Event {
+	sub := newQueue(opts.Buffer, opts.Overflow, ctx.Done())
+
+	// The channel is the queue, so the subscription itself never needs one.
+	id := c.addListener(sub.send, WithoutQueue())
+	context.AfterFunc(ctx, func() {
+		c.removeListener(id)
+		sub.close()
+	})
+
+	return sub.events
+}
@@ -0,0 +1,54 @@
+package events
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSubscribeOverflow(t *testing.T) {
+	policies := map[OverflowPolicy][]Event{
+		OverflowDropOldest: {WorkspaceEvent{WorkspaceName: "2"}, WorkspaceEvent{WorkspaceName: "3"}},
+		OverflowDropNewest: {WorkspaceEvent{WorkspaceName: "1"}, WorkspaceEvent{WorkspaceName: "2"}},
+	}
+
+	for policy, expected := range policies {
+		client := &Client{}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events := client.Subscribe(ctx, SubscribeOptions{Buffer: 2, Overflow: policy})
+
+		for _, name := range []string{"1", "2", "3"} {
+			client.dispatch(WorkspaceEvent{WorkspaceName: name})
+		}
+
+		cancel()
+
+		received := make([]Event, 0)
+		for event := range events {
+			received = append(received, event)
+		}
+
+		if len(received) != len(expected) || received[0] != expected[0] || received[1] != expected[1] {
+			t.Errorf("policy %d: expected %v, got %v", policy, expected, received)
+		}
+	}
+}
+
+func TestSubscribeBlockUnblocksOnCancel(t *testing.T) {
+	client := &Client{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := client.Subscribe(ctx, SubscribeOptions{})
+
+	done := make(chan struct{})
+	go func() {
+		client.dispatch(WorkspaceEvent{WorkspaceName: "1"})
+		close(done)
+	}()
+
+	cancel()
+	<-done
+
+	for range events {
+	}
+}
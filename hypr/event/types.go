@@ -4,14 +4,27 @@ type Event interface{}
 
 // UnhandledEvent Pass the raw event along in case the user wants to implement it.
 type UnhandledEvent struct {
-	Raw string
+	Raw string `json:"raw"`
 }
 
 // MalformedEvent Pass the raw event along and the error message in case the user
 // wants to handle it.
 type MalformedEvent struct {
-	Raw   string
-	Error error
+	Raw   string `json:"raw"`
+	Error error  `json:"error"`
+}
+
+// DisconnectedEvent is emitted by the Client, not Hyprland, when the event socket
+// connection is lost and a ReconnectPolicy is configured.
+type DisconnectedEvent struct {
+	Error error `json:"error"`
+}
+
+// ReconnectedEvent is emitted by the Client, not Hyprland, once the event socket
+// connection is re-established. Events sent while disconnected are lost, so
+// listeners should resync any state they keep.
+type ReconnectedEvent struct {
+	Attempts int `json:"attempts"`
 }
 
 // FullscreenMode represents if we are entering or exiting fullscreen mode.
@@ -31,37 +44,37 @@ const (
 // WorkspaceEvent emitted on workspace change. Is emitted ONLY when a user requests
 // a workspace change, and is not emitted on mouse movements (see focusedmon).
 type WorkspaceEvent struct {
-	WorkspaceName string
+	WorkspaceName string `json:"workspaceName"`
 }
 
 // WorkspaceV2Event emitted on workspace change. Is emitted ONLY when a user requests
 // a workspace change, and is not emitted on mouse movements (see focusedmon).
 type WorkspaceV2Event struct {
-	WorkspaceID   int
-	WorkspaceName string
+	WorkspaceID   int    `json:"workspaceID"`
+	WorkspaceName string `json:"workspaceName"`
 }
 
 // FocusedMonitorEvent emitted on the active monitor being changed.
 type FocusedMonitorEvent struct {
-	MonitorName   string
-	WorkspaceName string
+	MonitorName   string `json:"monitorName"`
+	WorkspaceName string `json:"workspaceName"`
 }
 
 // FocusedMonitorV2Event emitted on the active monitor being changed.
 type FocusedMonitorV2Event struct {
-	MonitorName string
-	WorkspaceID int
+	MonitorName string `json:"monitorName"`
+	WorkspaceID int    `json:"workspaceID"`
 }
 
 // ActiveWindowEvent emitted on the active window being changed.
 type ActiveWindowEvent struct {
-	WindowClass string
-	WindowTitle string
+	WindowClass string `json:"windowClass"`
+	WindowTitle string `json:"windowTitle"`
 }
 
 // ActiveWindowV2Event emitted on the active window being changed.
 type ActiveWindowV2Event struct {
-	WindowAddress string
+	WindowAddress string `json:"windowAddress"`
 }
 
 // FullscreenEvent emitted when a fullscreen status of a window changes.
@@ -70,192 +83,192 @@ type ActiveWindowV2Event struct {
 // Some windows may fire multiple requests to be fullscreened, resulting in
 // multiple fullscreen events.
 type FullscreenEvent struct {
-	FullscreenMode FullscreenMode
+	FullscreenMode FullscreenMode `json:"fullscreenMode"`
 }
 
 // MonitorRemovedEvent emitted when a monitor is removed (disconnected).
 type MonitorRemovedEvent struct {
-	MonitorName string
+	MonitorName string `json:"monitorName"`
 }
 
 // MonitorRemovedV2Event emitted when a monitor is removed (disconnected).
 type MonitorRemovedV2Event struct {
-	MonitorID          int
-	MonitorName        string
-	MonitorDescription string
+	MonitorID          int    `json:"monitorID"`
+	MonitorName        string `json:"monitorName"`
+	MonitorDescription string `json:"monitorDescription"`
 }
 
 // MonitorAddedEvent emitted when a monitor is added (connected).
 type MonitorAddedEvent struct {
-	MonitorName string
+	MonitorName string `json:"monitorName"`
 }
 
 // MonitorAddedV2Event emitted when a monitor is added (connected).
 type MonitorAddedV2Event struct {
-	MonitorID          int
-	MonitorName        string
-	MonitorDescription string
+	MonitorID          int    `json:"monitorID"`
+	MonitorName        string `json:"monitorName"`
+	MonitorDescription string `json:"monitorDescription"`
 }
 
 // CreateWorkspaceEvent emitted when a workspace is created.
 type CreateWorkspaceEvent struct {
-	WorkspaceName string
+	WorkspaceName string `json:"workspaceName"`
 }
 
 // CreateWorkspaceV2Event emitted when a workspace is created.
 type CreateWorkspaceV2Event struct {
-	WorkspaceID   int
-	WorkspaceName string
+	WorkspaceID   int    `json:"workspaceID"`
+	WorkspaceName string `json:"workspaceName"`
 }
 
 // DestroyWorkspaceEvent emitted when a workspace is destroyed.
 type DestroyWorkspaceEvent struct {
-	WorkspaceName string
+	WorkspaceName string `json:"workspaceName"`
 }
 
 // DestroyWorkspaceV2Event emitted when a workspace is destroyed.
 type DestroyWorkspaceV2Event struct {
-	WorkspaceID   int
-	WorkspaceName string
+	WorkspaceID   int    `json:"workspaceID"`
+	WorkspaceName string `json:"workspaceName"`
 }
 
 // MoveWorkspaceEvent emitted when a workspace is moved to a different monitor.
 type MoveWorkspaceEvent struct {
-	WorkspaceName string
-	MonitorName   string
+	WorkspaceName string `json:"workspaceName"`
+	MonitorName   string `json:"monitorName"`
 }
 
 // MoveWorkspaceV2Event emitted when a workspace is moved to a different monitor.
 type MoveWorkspaceV2Event struct {
-	WorkspaceID   int
-	WorkspaceName string
-	MonitorName   string
+	WorkspaceID   int    `json:"workspaceID"`
+	WorkspaceName string `json:"workspaceName"`
+	MonitorName   string `json:"monitorName"`
 }
 
 // RenameWorkspaceEvent emitted when a workspace is renamed.
 type RenameWorkspaceEvent struct {
-	WorkspaceID      int
-	NewWorkspaceName string
+	WorkspaceID      int    `json:"workspaceID"`
+	NewWorkspaceName string `json:"newWorkspaceName"`
 }
 
 // ActiveSpecialEvent emitted when the special workspace opened in a monitor changes
 // (closing results in an empty WorkspaceName).
 type ActiveSpecialEvent struct {
-	WorkspaceName string
-	MonitorName   string
+	WorkspaceName string `json:"workspaceName"`
+	MonitorName   string `json:"monitorName"`
 }
 
 // ActiveSpecialV2Event emitted when the special workspace opened in a monitor changes
 // (closing results in empty WorkspaceID and WorkspaceName values).
 type ActiveSpecialV2Event struct {
-	WorkspaceID   int
-	WorkspaceName string
-	MonitorName   string
+	WorkspaceID   int    `json:"workspaceID"`
+	WorkspaceName string `json:"workspaceName"`
+	MonitorName   string `json:"monitorName"`
 }
 
 // ActiveLayoutEvent emitted on a layout change of the active keyboard.
 type ActiveLayoutEvent struct {
-	KeyboardName string
-	LayoutName   string
+	KeyboardName string `json:"keyboardName"`
+	LayoutName   string `json:"layoutName"`
 }
 
 // OpenWindowEvent emitted when a window is opened.
 type OpenWindowEvent struct {
-	WindowAddress string
-	WorkspaceName string
-	WindowClass   string
-	WindowTitle   string
+	WindowAddress string `json:"windowAddress"`
+	WorkspaceName string `json:"workspaceName"`
+	WindowClass   string `json:"windowClass"`
+	WindowTitle   string `json:"windowTitle"`
 }
 
 // CloseWindowEvent emitted when a window is closed.
 type CloseWindowEvent struct {
-	WindowAddress string
+	WindowAddress string `json:"windowAddress"`
 }
 
 // MoveWindowEvent emitted when a window is moved to a workspace.
 type MoveWindowEvent struct {
-	WindowAddress string
-	WorkspaceName string
+	WindowAddress string `json:"windowAddress"`
+	WorkspaceName string `json:"workspaceName"`
 }
 
 // MoveWindowV2Event emitted when a window is moved to a workspace.
 type MoveWindowV2Event struct {
-	WindowAddress string
-	WorkspaceID   int
-	WorkspaceName string
+	WindowAddress string `json:"windowAddress"`
+	WorkspaceID   int    `json:"workspaceID"`
+	WorkspaceName string `json:"workspaceName"`
 }
 
 // OpenLayerEvent emitted when a layerSurface is mapped.
 type OpenLayerEvent struct {
-	Namespace string
+	Namespace string `json:"namespace"`
 }
 
 // CloseLayerEvent emitted when a layerSurface is unmapped.
 type CloseLayerEvent struct {
-	Namespace string
+	Namespace string `json:"namespace"`
 }
 
 // SubmapEvent emitted when a keybind submap changes.
 // Empty means default.
 type SubmapEvent struct {
-	SubmapName string
+	SubmapName string `json:"submapName"`
 }
 
 // ChangeFloatingModeEvent emitted when a window changes its floating mode.
 type ChangeFloatingModeEvent struct {
-	WindowAddress string
-	Floating      bool
+	WindowAddress string `json:"windowAddress"`
+	Floating      bool   `json:"floating"`
 }
 
 // UrgentEvent emitted when a window requests an urgent state.
 type UrgentEvent struct {
-	WindowAddress string
+	WindowAddress string `json:"windowAddress"`
 }
 
 // ScreencastEvent emitted when a screencopy state of a client changes.
 // Keep in mind there might be multiple separate clients. ScreencastState is 0/1,
 // Owner is 0 - ScreencastMonitor, 1 - ScreencastWindow
 type ScreencastEvent struct {
-	ScreencastState int
-	Owner           ScreencastOwner
+	ScreencastState int             `json:"screencastState"`
+	Owner           ScreencastOwner `json:"owner"`
 }
 
 // WindowTitleEvent emitted when a window title changes.
 type WindowTitleEvent struct {
-	WindowAddress string
+	WindowAddress string `json:"windowAddress"`
 }
 
 // WindowTitleV2Event emitted when a window title changes.
 type WindowTitleV2Event struct {
-	WindowAddress string
-	WindowTitle   string
+	WindowAddress string `json:"windowAddress"`
+	WindowTitle   string `json:"windowTitle"`
 }
 
 // ToggleGroupEvent emitted when togglegroup command is used.
 // The GroupState is a toggle status where 0 means the group has been destroyed.
 type ToggleGroupEvent struct {
-	GroupState      int
-	WindowAddresses []string
+	GroupState      int      `json:"groupState"`
+	WindowAddresses []string `json:"windowAddresses"`
 }
 
 // MoveIntoGroupEvent emitted when the window is merged into a group.
 type MoveIntoGroupEvent struct {
-	WindowAddress string
+	WindowAddress string `json:"windowAddress"`
 }
 
 // MoveOutOfGroupEvent emitted when the window is removed from a group.
 type MoveOutOfGroupEvent struct {
-	WindowAddress string
+	WindowAddress string `json:"windowAddress"`
 }
 
 // IgnoreGroupLockEvent emitted when ignoregrouplock is toggled.
 type IgnoreGroupLockEvent struct {
-	Ignored bool
+	Ignored bool `json:"ignored"`
 }
 
 // LockGroupsEvent emitted when lockgroups is toggled.
 type LockGroupsEvent struct {
-	Locked bool
+	Locked bool `json:"locked"`
 }
 
 // ConfigReloadEvent emitted when the config is done reloading.
@@ -263,19 +276,25 @@ type ConfigReloadEvent struct{}
 
 // PinEvent emitted when a window is pinned or unpinned.
 type PinEvent struct {
-	WindowAddress string
-	Pinned        bool
+	WindowAddress string `json:"windowAddress"`
+	Pinned        bool   `json:"pinned"`
 }
 
 // MinimizedEvent emitted when an external taskbar-like app requests
 // a window to be minimized.
 type MinimizedEvent struct {
-	WindowAddress string
-	Minimized     bool
+	WindowAddress string `json:"windowAddress"`
+	Minimized     bool   `json:"minimized"`
 }
 
 // BellEvent emitted when an app requests to ring the system bell via
 // `xdg-system-bell-v1`. Window address parameter may be empty
 type BellEvent struct {
-	WindowAddress string
+	WindowAddress string `json:"windowAddress"`
+}
+
+// CustomEvent emitted when the event dispatcher is called with `dispatch event <data>`,
+// for example through hypr.Request.EmitEvent.
+type CustomEvent struct {
+	Data string `json:"data"`
 }
@@ -0,0 +1,437 @@
+// Package hyprtest runs a fake Hyprland instance for tests of code built on the
+// hypr and events packages.
+//
+// A Server creates a runtime directory with the request socket (.socket.sock),
+// the event socket (.socket2.sock) and a lock file, so both clients and the
+// instance package find it like a real compositor:
+//
+//	srv := hyprtest.NewServer(t)
+//	srv.RespondJSON("j/clients", []hypr.Window{{Address: "0x1", Class: "kitty"}})
+//
+//	client, err := hypr.NewClient(hypr.WithInstance(srv.Instance()))
+package hyprtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	events "github.com/jstncnnr/go-hyprland/hypr/event"
+	"github.com/jstncnnr/go-hyprland/hypr/instance"
+)
+
+// DefaultSignature is the instance signature used by NewServer.
+const DefaultSignature = "hyprtest"
+
+// Handler answers a single command, such as "j/clients" or "dispatch exec kitty".
+// Commands of a batch are passed one at a time.
+type Handler func(command string) string
+
+// Server is a fake Hyprland instance. Commands without a registered response are
+// answered with "ok".
+type Server struct {
+	instance instance.Instance
+
+	requestListener net.Listener
+	eventListener   net.Listener
+
+	mu        sync.Mutex
+	responses map[string]string
+	handler   Handler
+	requests  []string
+	commands  []string
+
+	eventConns []net.Conn
+
+	// changed is closed and replaced whenever an event client connects or leaves.
+	changed chan struct{}
+
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewServer starts a Server in a new temporary runtime directory. It is shut
+// down when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	// Unix socket paths are limited to about 100 bytes, which t.TempDir exceeds
+	// on some systems.
+	runtimeDir, err := os.MkdirTemp("", "hypr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(runtimeDir) })
+
+	s := &Server{
+		instance: instance.Instance{
+			Signature:      DefaultSignature,
+			PID:            os.Getpid(),
+			WaylandDisplay: "wayland-hyprtest",
+			RuntimeDir:     runtimeDir,
+		},
+		responses: make(map[string]string),
+		changed:   make(chan struct{}),
+	}
+
+	if err := s.start(); err != nil {
+		s.Close()
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *Server) start() error {
+	if err := os.MkdirAll(s.instance.Dir(), 0o755); err != nil {
+		return err
+	}
+
+	lock := fmt.Sprintf("%d\n%s\n", s.instance.PID, s.instance.WaylandDisplay)
+	if err := os.WriteFile(filepath.Join(s.instance.Dir(), "hyprland.lock"), []byte(lock), 0o644); err != nil {
+		return err
+	}
+
+	var err error
+	s.requestListener, err = net.Listen("unix", s.instance.SocketPath())
+	if err != nil {
+		return err
+	}
+
+	s.eventListener, err = net.Listen("unix", s.instance.EventSocketPath())
+	if err != nil {
+		return err
+	}
+
+	s.wg.Add(2)
+	go s.acceptRequests()
+	go s.acceptEventClients()
+	return nil
+}
+
+// Instance returns the fake instance, for use with hypr.WithInstance and
+// events.WithInstance.
+func (s *Server) Instance() instance.Instance {
+	return s.instance
+}
+
+// Setenv points HYPRLAND_INSTANCE_SIGNATURE and XDG_RUNTIME_DIR at the server
+// for the duration of the test, so clients created without options use it.
+func (s *Server) Setenv(t testing.TB) {
+	t.Helper()
+	t.Setenv("XDG_RUNTIME_DIR", s.instance.RuntimeDir)
+	t.Setenv("HYPRLAND_INSTANCE_SIGNATURE", s.instance.Signature)
+}
+
+// Respond registers the reply to a command. The command is matched exactly,
+// including the "j/" prefix of JSON queries.
+func (s *Server) Respond(command string, response string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses[command] = response
+}
+
+// RespondJSON registers v encoded as JSON as the reply to a command.
+func (s *Server) RespondJSON(command string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("hyprtest: encoding response to %s: %v", command, err))
+	}
+
+	s.Respond(command, string(data))
+}
+
+// HandleFunc sets the handler for commands without a registered response.
+func (s *Server) HandleFunc(handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handler = handler
+}
+
+// Requests returns the requests received so far, exactly as they were written to
+// the socket. A batch is a single request.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+// Commands returns the commands received so far, with batches split into their
+// commands.
+func (s *Server) Commands() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.commands...)
+}
+
+func (s *Server) acceptRequests() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.requestListener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serveRequest(conn)
+		}()
+	}
+}
+
+// requestIdleTimeout is how long serveRequest waits for more of a request once
+// data stops arriving. Clients do not close their side after writing, so a pause
+// marks the end of the request.
+const requestIdleTimeout = 20 * time.Millisecond
+
+// serveRequest answers one request and closes the connection, like Hyprland.
+func (s *Server) serveRequest(conn net.Conn) {
+	defer conn.Close()
+
+	request, err := readRequest(conn)
+	if err != nil {
+		return
+	}
+
+	_, _ = conn.Write([]byte(s.answer(request)))
+}
+
+// readRequest reads until the client closes its side or stops writing, so
+// requests larger than a single read or split across writes arrive whole.
+func readRequest(conn net.Conn) (string, error) {
+	var request []byte
+	buffer := make([]byte, 1<<16)
+	for {
+		n, err := conn.Read(buffer)
+		request = append(request, buffer[:n]...)
+
+		var netErr net.Error
+		switch {
+		case err == nil:
+		case errors.As(err, &netErr) && netErr.Timeout(), errors.Is(err, io.EOF):
+			if len(request) == 0 {
+				return "", err
+			}
+
+			return string(request), nil
+		default:
+			return "", err
+		}
+
+		_ = conn.SetReadDeadline(time.Now().Add(requestIdleTimeout))
+	}
+}
+
+func (s *Server) answer(request string) string {
+	commands := []string{request}
+	if batch, ok := strings.CutPrefix(request, "[[BATCH]]"); ok {
+		commands = splitBatch(batch)
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, request)
+	s.mu.Unlock()
+
+	replies := make([]string, 0, len(commands))
+	for _, command := range commands {
+		command = strings.TrimSpace(command)
+
+		s.mu.Lock()
+		s.commands = append(s.commands, command)
+		reply, ok := s.responses[command]
+		handler := s.handler
+		s.mu.Unlock()
+
+		// The handler runs without holding the lock, so it may emit events.
+		switch {
+		case ok:
+		case handler != nil:
+			reply = handler(command)
+		default:
+			reply = "ok"
+		}
+
+		replies = append(replies, reply)
+	}
+
+	return strings.Join(replies, "\n\n\n")
+}
+
+// splitBatch splits a batch into its commands like Hyprland, which ignores
+// semicolons inside brackets such as the rules of "exec [workspace 2; float]".
+func splitBatch(batch string) []string {
+	commands := make([]string, 0)
+
+	depth, start := 0, 0
+	for i, char := range batch {
+		switch char {
+		case '[':
+			depth++
+		case ']':
+			depth = max(depth-1, 0)
+		case ';':
+			if depth == 0 {
+				commands = append(commands, batch[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(commands, batch[start:])
+}
+
+func (s *Server) acceptEventClients() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.eventListener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.eventConns = append(s.eventConns, conn)
+		s.notify()
+		s.mu.Unlock()
+	}
+}
+
+// notify wakes up WaitForEventClients. s.mu must be held.
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// EventClients returns the number of connected event clients.
+func (s *Server) EventClients() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.eventConns)
+}
+
+// WaitForEventClients waits until at least n event clients are connected, so that
+// events emitted afterwards are not missed.
+func (s *Server) WaitForEventClients(ctx context.Context, n int) error {
+	for {
+		s.mu.Lock()
+		count, changed := len(s.eventConns), s.changed
+		s.mu.Unlock()
+
+		if count >= n {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// Emit writes raw lines, such as "workspace>>2", to every connected event client.
+// Clients that fail to receive them are disconnected. The lines are written
+// without holding the server lock, so a client that stops reading only blocks
+// Emit.
+func (s *Server) Emit(lines ...string) {
+	var data strings.Builder
+	for _, line := range lines {
+		data.WriteString(line)
+		data.WriteString("\n")
+	}
+
+	s.mu.Lock()
+	conns := slices.Clone(s.eventConns)
+	s.mu.Unlock()
+
+	failed := make([]net.Conn, 0)
+	for _, conn := range conns {
+		if _, err := conn.Write([]byte(data.String())); err != nil {
+			_ = conn.Close()
+			failed = append(failed, conn)
+		}
+	}
+
+	if len(failed) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.eventConns = slices.DeleteFunc(s.eventConns, func(conn net.Conn) bool {
+		return slices.Contains(failed, conn)
+	})
+	s.notify()
+}
+
+// EmitEvents encodes events to their wire format and emits them.
+func (s *Server) EmitEvents(evts ...events.Event) error {
+	lines := make([]string, 0, len(evts))
+	for _, event := range evts {
+		line, err := events.Encode(event)
+		if err != nil {
+			return err
+		}
+
+		lines = append(lines, line)
+	}
+
+	s.Emit(lines...)
+	return nil
+}
+
+// Replay emits the lines of a recording to the event clients at the pace of the
+// replayer. It returns once the replay is finished or ctx is cancelled.
+func (s *Server) Replay(ctx context.Context, replayer *events.Replayer) error {
+	return replayer.Run(ctx, func(line string) {
+		s.Emit(line)
+	})
+}
+
+// DisconnectEventClients closes the connections of all event clients, as if the
+// compositor had restarted.
+func (s *Server) DisconnectEventClients() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, conn := range s.eventConns {
+		_ = conn.Close()
+	}
+
+	s.eventConns = nil
+	s.notify()
+}
+
+// Close stops the server and disconnects all clients. It is called automatically
+// when the test finishes.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		for _, listener := range []net.Listener{s.requestListener, s.eventListener} {
+			if listener != nil {
+				_ = listener.Close()
+			}
+		}
+
+		s.DisconnectEventClients()
+		s.wg.Wait()
+	})
+}
@@ -0,0 +1,212 @@
+package hyprtest
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jstncnnr/go-hyprland/hypr"
+	"github.com/jstncnnr/go-hyprland/hypr/commands"
+	events "github.com/jstncnnr/go-hyprland/hypr/event"
+)
+
+func TestServerRequests(t *testing.T) {
+	srv := NewServer(t)
+	srv.RespondJSON("j/clients", []hypr.Window{{Address: "0x1", Class: "kitty"}})
+
+	client, err := hypr.NewClient(hypr.WithInstance(srv.Instance()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	windows, err := client.GetWindows()
+	if err != nil || len(windows) != 1 || windows[0].Class != "kitty" {
+		t.Fatalf("expected the canned windows, got %v, %v", windows, err)
+	}
+
+	_, err = client.Send(hypr.NewRequest().
+		Workspace(commands.WorkspaceByID(2)).
+		Reload())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"j/clients", "dispatch workspace 2", "reload"}
+	if commands := srv.Commands(); !slices.Equal(commands, expected) {
+		t.Errorf("expected commands %q, got %q", expected, commands)
+	}
+
+	if requests := srv.Requests(); len(requests) != 2 || requests[1] != "[[BATCH]]dispatch workspace 2 ; reload" {
+		t.Errorf("expected the batch as a single request, got %q", requests)
+	}
+}
+
+func TestServerBatchWithRules(t *testing.T) {
+	srv := NewServer(t)
+	srv.Respond("reload", "reloaded")
+
+	client, err := hypr.NewClient(hypr.WithInstance(srv.Instance()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The reply to reload is not "ok", so only its result fails.
+	result, _ := client.Send(hypr.NewRequest().
+		ExecWithRules("[workspace 2 silent; float]", "kitty").
+		Reload())
+	if len(result.Results) != 2 || !result.Results[0].OK() || result.Results[1].Response != "reloaded" {
+		t.Fatalf("expected the replies to line up with their commands, got %+v", result.Results)
+	}
+
+	expected := []string{"dispatch exec [workspace 2 silent; float] kitty", "reload"}
+	if commands := srv.Commands(); !slices.Equal(commands, expected) {
+		t.Errorf("expected commands %q, got %q", expected, commands)
+	}
+}
+
+func TestServerLargeRequest(t *testing.T) {
+	srv := NewServer(t)
+
+	client, err := hypr.NewClient(hypr.WithInstance(srv.Instance()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := map[string]string{"text": strings.Repeat("x", 1<<18)}
+	if _, err := client.Send(hypr.NewRequest().EmitJSONEvent(payload)); err != nil {
+		t.Fatal(err)
+	}
+
+	commands := srv.Commands()
+	if len(commands) != 1 || !strings.HasSuffix(commands[0], strings.Repeat("x", 1<<18)+`"}`) {
+		t.Errorf("expected the whole request, got %d commands", len(commands))
+	}
+}
+
+func TestServerSetenv(t *testing.T) {
+	srv := NewServer(t)
+	srv.Setenv(t)
+	srv.HandleFunc(func(command string) string {
+		return "unknown request"
+	})
+
+	if _, err := hypr.NewRequest().Reload().Send(); err == nil {
+		t.Error("expected the handler to reject the command")
+	}
+}
+
+func TestServerEmit(t *testing.T) {
+	srv := NewServer(t)
+
+	client, err := events.NewClient(events.WithInstance(srv.Instance()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	received := client.Subscribe(ctx, events.SubscribeOptions{Buffer: 2})
+	go func() { _ = client.Listen(ctx) }()
+
+	if err := srv.WaitForEventClients(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.Emit("workspace>>2")
+	if err := srv.EmitEvents(events.ActiveWindowEvent{WindowClass: "kitty", WindowTitle: "~"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []events.Event{
+		events.WorkspaceEvent{WorkspaceName: "2"},
+		events.ActiveWindowEvent{WindowClass: "kitty", WindowTitle: "~"},
+	}
+
+	for _, want := range expected {
+		select {
+		case event := <-received:
+			if event != want {
+				t.Errorf("expected %#v, got %#v", want, event)
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for events")
+		}
+	}
+}
+
+func TestServerReplay(t *testing.T) {
+	srv := NewServer(t)
+
+	client, err := events.NewClient(events.WithInstance(srv.Instance()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	received := client.Subscribe(ctx, events.SubscribeOptions{Buffer: 2})
+	go func() { _ = client.Listen(ctx) }()
+
+	if err := srv.WaitForEventClients(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	records := []events.Record{
+		{Offset: time.Second, Line: "workspace>>2"},
+		{Offset: 2 * time.Second, Line: "focusedmon>>DP-1,2"},
+	}
+	if err := srv.Replay(ctx, events.NewReplayer(records, events.WithoutDelay())); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, record := range records {
+		select {
+		case event := <-received:
+			if event != events.Parse(record.Line) {
+				t.Errorf("expected %#v, got %#v", events.Parse(record.Line), event)
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for events")
+		}
+	}
+}
+
+func TestServerEmitDoesNotBlockOnStalledClient(t *testing.T) {
+	srv := NewServer(t)
+
+	// A client that never reads, so its socket buffer fills up.
+	conn, err := net.Dial("unix", srv.Instance().EventSocketPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := srv.WaitForEventClients(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	go srv.Emit(strings.Repeat("x", 8<<20))
+
+	done := make(chan struct{})
+	go func() {
+		srv.Respond("version", "hyprtest")
+		_ = srv.Commands()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the server to stay usable while Emit is blocked")
+	}
+}
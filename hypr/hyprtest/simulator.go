@@ -0,0 +1,887 @@
+package hyprtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jstncnnr/go-hyprland/hypr"
+	events "github.com/jstncnnr/go-hyprland/hypr/event"
+)
+
+// Simulator is a Server that models monitors, workspaces and windows. It applies
+// the dispatches it receives to the model, answers JSON queries from it and emits
+// the events Hyprland would, in the same order.
+//
+// The following are simulated:
+//
+//	dispatch workspace, movetoworkspace, movetoworkspacesilent, togglefloating,
+//	         setfloating, settiled, pin, closewindow, killactive, focuswindow,
+//	         focusmonitor, event
+//	keyword monitor NAME,addreserved,TOP,BOTTOM,LEFT,RIGHT
+//	j/monitors, j/workspaces, j/clients, j/activewindow, j/activeworkspace,
+//	j/layers, j/devices
+//
+// Other commands are answered with "ok" and leave the model unchanged. Responses
+// registered with Respond take precedence over the model.
+//
+// Windows are opened and closed by applications rather than dispatches, which
+// tests do with OpenWindow and CloseWindow. Relative workspace selectors such as
+// "+1" or "m-1" move by workspace ID, and special workspaces are not supported.
+type Simulator struct {
+	*Server
+
+	mu            sync.Mutex
+	emitMu        sync.Mutex
+	monitors      []*simMonitor
+	workspaces    []*simWorkspace
+	windows       []*simWindow
+	focused       *simMonitor
+	active        *simWindow
+	previous      int
+	focusHistory  []*simWindow
+	nextAddress   uint64
+	nextMonitorID int
+	pending       []events.Event
+}
+
+type simMonitor struct {
+	id          int
+	name        string
+	description string
+	width       int
+	height      int
+	x           int
+	workspace   *simWorkspace
+	reserved    []int
+}
+
+type simWorkspace struct {
+	id         int
+	name       string
+	monitor    *simMonitor
+	lastWindow *simWindow
+}
+
+type simWindow struct {
+	address   string
+	class     string
+	title     string
+	pid       int
+	workspace *simWorkspace
+	floating  bool
+	pinned    bool
+}
+
+// NewSimulator starts a Simulator with a single 1920x1080 monitor named "DP-1"
+// showing workspace 1. It is shut down when the test finishes.
+func NewSimulator(t testing.TB) *Simulator {
+	t.Helper()
+
+	s := &Simulator{
+		Server:      NewServer(t),
+		nextAddress: 0x5a0000,
+	}
+
+	s.AddMonitor("DP-1", 1920, 1080)
+	s.HandleFunc(s.handle)
+	return s
+}
+
+// AddMonitor connects a monitor to the right of the existing ones. It shows the
+// first free workspace and is focused when it is the only monitor.
+func (s *Simulator) AddMonitor(name string, width int, height int) hypr.Monitor {
+	s.mu.Lock()
+	defer s.unlockAndFlush()
+
+	x := 0
+	for _, monitor := range s.monitors {
+		x = max(x, monitor.x+monitor.width)
+	}
+
+	monitor := &simMonitor{
+		id:          s.nextMonitorID,
+		name:        name,
+		description: "hyprtest " + name,
+		width:       width,
+		height:      height,
+		x:           x,
+		reserved:    []int{0, 0, 0, 0},
+	}
+	s.nextMonitorID++
+	s.monitors = append(s.monitors, monitor)
+
+	id := s.freeWorkspaceID()
+	monitor.workspace = s.createWorkspace(id, strconv.Itoa(id), monitor)
+
+	s.emit(
+		events.MonitorAddedEvent{MonitorName: name},
+		events.MonitorAddedV2Event{MonitorID: monitor.id, MonitorName: name, MonitorDescription: monitor.description},
+	)
+
+	if s.focused == nil {
+		s.focused = monitor
+	}
+
+	return s.monitorJSON(monitor)
+}
+
+// OpenWindow maps a new window on the active workspace of the focused monitor and
+// focuses it, as if an application had opened it.
+func (s *Simulator) OpenWindow(class string, title string) hypr.Window {
+	s.mu.Lock()
+	defer s.unlockAndFlush()
+
+	window := &simWindow{
+		address:   fmt.Sprintf("0x%x", s.nextAddress),
+		class:     class,
+		title:     title,
+		pid:       1000 + len(s.windows),
+		workspace: s.focused.workspace,
+	}
+	s.nextAddress += 0x10
+	s.windows = append(s.windows, window)
+
+	s.emit(events.OpenWindowEvent{
+		WindowAddress: eventAddress(window),
+		WorkspaceName: window.workspace.name,
+		WindowClass:   class,
+		WindowTitle:   title,
+	})
+	s.focus(window)
+
+	return s.windowJSON(window)
+}
+
+// CloseWindow unmaps the window with the given address, as if its application had
+// closed it. It reports whether the window existed.
+func (s *Simulator) CloseWindow(address string) bool {
+	s.mu.Lock()
+	defer s.unlockAndFlush()
+
+	window := s.windowByAddress(address)
+	if window == nil {
+		return false
+	}
+
+	s.closeWindow(window)
+	return true
+}
+
+// Monitors returns the monitors as j/monitors reports them.
+func (s *Simulator) Monitors() []hypr.Monitor {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	monitors := make([]hypr.Monitor, 0, len(s.monitors))
+	for _, monitor := range s.monitors {
+		monitors = append(monitors, s.monitorJSON(monitor))
+	}
+
+	return monitors
+}
+
+// Workspaces returns the workspaces as j/workspaces reports them.
+func (s *Simulator) Workspaces() []hypr.Workspace {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workspaces := make([]hypr.Workspace, 0, len(s.workspaces))
+	for _, workspace := range s.workspaces {
+		workspaces = append(workspaces, s.workspaceJSON(workspace))
+	}
+
+	return workspaces
+}
+
+// Windows returns the windows as j/clients reports them.
+func (s *Simulator) Windows() []hypr.Window {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	windows := make([]hypr.Window, 0, len(s.windows))
+	for _, window := range s.windows {
+		windows = append(windows, s.windowJSON(window))
+	}
+
+	return windows
+}
+
+// ActiveWindow returns the focused window, if any.
+func (s *Simulator) ActiveWindow() (hypr.Window, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active == nil {
+		return hypr.Window{}, false
+	}
+
+	return s.windowJSON(s.active), true
+}
+
+// ActiveWorkspace returns the workspace shown on the focused monitor.
+func (s *Simulator) ActiveWorkspace() hypr.Workspace {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.workspaceJSON(s.focused.workspace)
+}
+
+func (s *Simulator) handle(command string) string {
+	s.mu.Lock()
+	defer s.unlockAndFlush()
+
+	if dispatch, ok := strings.CutPrefix(command, "dispatch "); ok {
+		name, args, _ := strings.Cut(dispatch, " ")
+		return s.dispatch(name, strings.TrimSpace(args))
+	}
+
+	if keyword, ok := strings.CutPrefix(command, "keyword "); ok {
+		return s.keyword(keyword)
+	}
+
+	if query, ok := strings.CutPrefix(command, "j/"); ok {
+		return s.query(query)
+	}
+
+	return "ok"
+}
+
+func (s *Simulator) dispatch(name string, args string) string {
+	switch name {
+	case "workspace":
+		id, workspaceName, ok := s.resolveWorkspace(args)
+		if !ok {
+			return "Invalid workspace"
+		}
+
+		s.switchWorkspace(id, workspaceName)
+	case "movetoworkspace", "movetoworkspacesilent":
+		workspaceArg, windowArg, _ := strings.Cut(args, ",")
+
+		window := s.resolveWindow(windowArg)
+		if window == nil {
+			return "No such window found"
+		}
+
+		id, workspaceName, ok := s.resolveWorkspace(workspaceArg)
+		if !ok {
+			return "Invalid workspace"
+		}
+
+		s.moveToWorkspace(window, id, workspaceName, name == "movetoworkspacesilent")
+	case "togglefloating", "setfloating", "settiled":
+		window := s.optionalWindow(args)
+		if window == nil {
+			return "No such window found"
+		}
+
+		floating := name == "setfloating" || (name == "togglefloating" && !window.floating)
+		if window.floating != floating {
+			window.floating = floating
+			s.emit(events.ChangeFloatingModeEvent{WindowAddress: eventAddress(window), Floating: floating})
+		}
+	case "pin":
+		window := s.optionalWindow(args)
+		if window == nil {
+			return "No such window found"
+		}
+
+		window.pinned = !window.pinned
+		s.emit(events.PinEvent{WindowAddress: eventAddress(window), Pinned: window.pinned})
+	case "closewindow", "killactive":
+		window := s.active
+		if name == "closewindow" {
+			window = s.resolveWindow(args)
+		}
+
+		if window != nil {
+			s.closeWindow(window)
+		}
+	case "focuswindow":
+		window := s.resolveWindow(args)
+		if window == nil {
+			return "No such window found"
+		}
+
+		// Switching to the workspace focuses its last window, which is the one
+		// requested here.
+		window.workspace.lastWindow = window
+		s.switchWorkspace(window.workspace.id, window.workspace.name)
+		s.focus(window)
+	case "focusmonitor":
+		monitor := s.resolveMonitor(args)
+		if monitor == nil {
+			return "Monitor not found"
+		}
+
+		s.focusMonitor(monitor)
+		s.focus(monitor.workspace.lastWindow)
+	case "event":
+		s.emit(events.CustomEvent{Data: args})
+	}
+
+	return "ok"
+}
+
+func (s *Simulator) keyword(keyword string) string {
+	name, value, _ := strings.Cut(keyword, " ")
+	if name != "monitor" {
+		return "ok"
+	}
+
+	fields := strings.Split(value, ",")
+	if len(fields) != 6 || fields[1] != "addreserved" {
+		return "ok"
+	}
+
+	monitor := s.resolveMonitor(fields[0])
+	if monitor == nil {
+		return "Monitor not found"
+	}
+
+	reserved := make([]int, 4)
+	for index, field := range fields[2:] {
+		value, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return "invalid reserved value " + field
+		}
+
+		reserved[index] = value
+	}
+
+	// addreserved takes top, bottom, left, right while j/monitors reports left,
+	// top, right, bottom.
+	monitor.reserved = []int{reserved[2], reserved[0], reserved[3], reserved[1]}
+	return "ok"
+}
+
+func (s *Simulator) query(query string) string {
+	var v any
+	switch query {
+	case "monitors":
+		monitors := make([]hypr.Monitor, 0, len(s.monitors))
+		for _, monitor := range s.monitors {
+			monitors = append(monitors, s.monitorJSON(monitor))
+		}
+
+		v = monitors
+	case "workspaces":
+		workspaces := make([]hypr.Workspace, 0, len(s.workspaces))
+		for _, workspace := range s.workspaces {
+			workspaces = append(workspaces, s.workspaceJSON(workspace))
+		}
+
+		v = workspaces
+	case "clients":
+		windows := make([]hypr.Window, 0, len(s.windows))
+		for _, window := range s.windows {
+			windows = append(windows, s.windowJSON(window))
+		}
+
+		v = windows
+	case "activewindow":
+		if s.active == nil {
+			return "{}"
+		}
+
+		v = s.windowJSON(s.active)
+	case "activeworkspace":
+		v = s.workspaceJSON(s.focused.workspace)
+	case "layers":
+		v = hypr.LayerTable{}
+	case "devices":
+		v = hypr.DeviceTable{}
+	case "binds":
+		v = []hypr.Bind{}
+	default:
+		return "unknown request"
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(data)
+}
+
+// emit queues events to be sent once the current change is applied.
+func (s *Simulator) emit(evts ...events.Event) {
+	s.pending = append(s.pending, evts...)
+}
+
+// unlockAndFlush releases s.mu and sends the queued events. They are written
+// after the lock is released, so an event client that stops reading does not
+// block queries, and emitMu keeps the events of concurrent changes in order.
+func (s *Simulator) unlockAndFlush() {
+	pending := s.pending
+	s.pending = nil
+	if len(pending) == 0 {
+		s.mu.Unlock()
+		return
+	}
+
+	s.emitMu.Lock()
+	defer s.emitMu.Unlock()
+	s.mu.Unlock()
+
+	if err := s.EmitEvents(pending...); err != nil {
+		panic(fmt.Sprintf("hyprtest: %v", err))
+	}
+}
+
+func (s *Simulator) freeWorkspaceID() int {
+	id := 1
+	for s.workspaceByID(id) != nil {
+		id++
+	}
+
+	return id
+}
+
+func (s *Simulator) workspaceByID(id int) *simWorkspace {
+	for _, workspace := range s.workspaces {
+		if workspace.id == id {
+			return workspace
+		}
+	}
+
+	return nil
+}
+
+func (s *Simulator) createWorkspace(id int, name string, monitor *simMonitor) *simWorkspace {
+	workspace := &simWorkspace{id: id, name: name, monitor: monitor}
+	s.workspaces = append(s.workspaces, workspace)
+	slices.SortFunc(s.workspaces, func(a, b *simWorkspace) int {
+		return a.id - b.id
+	})
+
+	s.emit(
+		events.CreateWorkspaceEvent{WorkspaceName: name},
+		events.CreateWorkspaceV2Event{WorkspaceID: id, WorkspaceName: name},
+	)
+
+	return workspace
+}
+
+// destroyIfUnused removes a workspace that has no windows and is not shown on any
+// monitor.
+func (s *Simulator) destroyIfUnused(workspace *simWorkspace) {
+	if workspace.monitor.workspace == workspace {
+		return
+	}
+
+	for _, window := range s.windows {
+		if window.workspace == workspace {
+			return
+		}
+	}
+
+	s.workspaces = slices.DeleteFunc(s.workspaces, func(w *simWorkspace) bool {
+		return w == workspace
+	})
+
+	s.emit(
+		events.DestroyWorkspaceEvent{WorkspaceName: workspace.name},
+		events.DestroyWorkspaceV2Event{WorkspaceID: workspace.id, WorkspaceName: workspace.name},
+	)
+}
+
+// resolveWorkspace returns the ID and name of the workspace a selector refers to,
+// which may not exist yet.
+func (s *Simulator) resolveWorkspace(selector string) (int, string, bool) {
+	selector = strings.TrimSpace(selector)
+
+	if name, ok := strings.CutPrefix(selector, "name:"); ok {
+		for _, workspace := range s.workspaces {
+			if workspace.name == name {
+				return workspace.id, name, true
+			}
+		}
+
+		return s.freeWorkspaceID(), name, true
+	}
+
+	switch {
+	case selector == "previous" || selector == "previous_per_monitor":
+		if s.previous == 0 {
+			return 0, "", false
+		}
+
+		return s.previous, s.workspaceName(s.previous), true
+	case strings.HasPrefix(selector, "empty"):
+		id := 1
+		for s.hasWindows(id) {
+			id++
+		}
+
+		return id, s.workspaceName(id), true
+	case strings.HasPrefix(selector, "+") || strings.HasPrefix(selector, "-"):
+		return s.relativeWorkspace(selector)
+	case len(selector) > 1 && strings.ContainsRune("mre", rune(selector[0])) && strings.ContainsRune("+-", rune(selector[1])):
+		return s.relativeWorkspace(selector[1:])
+	}
+
+	id, err := strconv.Atoi(selector)
+	if err != nil || id <= 0 {
+		return 0, "", false
+	}
+
+	return id, s.workspaceName(id), true
+}
+
+func (s *Simulator) relativeWorkspace(offset string) (int, string, bool) {
+	delta, err := strconv.Atoi(offset)
+	if err != nil {
+		return 0, "", false
+	}
+
+	id := max(s.focused.workspace.id+delta, 1)
+	return id, s.workspaceName(id), true
+}
+
+func (s *Simulator) workspaceName(id int) string {
+	if workspace := s.workspaceByID(id); workspace != nil {
+		return workspace.name
+	}
+
+	return strconv.Itoa(id)
+}
+
+func (s *Simulator) hasWindows(id int) bool {
+	for _, window := range s.windows {
+		if window.workspace.id == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ensureWorkspace returns the workspace with the given ID, creating it on the
+// focused monitor when it does not exist.
+func (s *Simulator) ensureWorkspace(id int, name string) *simWorkspace {
+	if workspace := s.workspaceByID(id); workspace != nil {
+		return workspace
+	}
+
+	return s.createWorkspace(id, name, s.focused)
+}
+
+func (s *Simulator) switchWorkspace(id int, name string) {
+	workspace := s.ensureWorkspace(id, name)
+	s.focusMonitor(workspace.monitor)
+
+	monitor := workspace.monitor
+	current := monitor.workspace
+	if current == workspace {
+		return
+	}
+
+	monitor.workspace = workspace
+	s.previous = current.id
+
+	s.emit(
+		events.WorkspaceEvent{WorkspaceName: workspace.name},
+		events.WorkspaceV2Event{WorkspaceID: workspace.id, WorkspaceName: workspace.name},
+	)
+
+	s.focus(workspace.lastWindow)
+	s.destroyIfUnused(current)
+}
+
+func (s *Simulator) moveToWorkspace(window *simWindow, id int, name string, silent bool) {
+	source := window.workspace
+	target := s.ensureWorkspace(id, name)
+	if source == target {
+		return
+	}
+
+	window.workspace = target
+	if source.lastWindow == window {
+		source.lastWindow = nil
+	}
+
+	s.emit(
+		events.MoveWindowEvent{WindowAddress: eventAddress(window), WorkspaceName: target.name},
+		events.MoveWindowV2Event{WindowAddress: eventAddress(window), WorkspaceID: target.id, WorkspaceName: target.name},
+	)
+
+	if silent {
+		if s.active == window {
+			s.focus(s.lastFocusedOn(source))
+		}
+	} else {
+		target.lastWindow = window
+		s.switchWorkspace(target.id, target.name)
+		s.focus(window)
+	}
+
+	s.destroyIfUnused(source)
+}
+
+func (s *Simulator) closeWindow(window *simWindow) {
+	s.windows = slices.DeleteFunc(s.windows, func(w *simWindow) bool {
+		return w == window
+	})
+	s.focusHistory = slices.DeleteFunc(s.focusHistory, func(w *simWindow) bool {
+		return w == window
+	})
+
+	workspace := window.workspace
+	if workspace.lastWindow == window {
+		workspace.lastWindow = s.lastFocusedOn(workspace)
+	}
+
+	s.emit(events.CloseWindowEvent{WindowAddress: eventAddress(window)})
+
+	if s.active == window {
+		// Focus moves to the previously focused window, which may be none.
+		s.focus(s.lastFocusedOn(s.focused.workspace))
+	}
+
+	s.destroyIfUnused(workspace)
+}
+
+// lastFocusedOn returns the most recently focused window on a workspace.
+func (s *Simulator) lastFocusedOn(workspace *simWorkspace) *simWindow {
+	for _, window := range s.focusHistory {
+		if window.workspace == workspace {
+			return window
+		}
+	}
+
+	return nil
+}
+
+func (s *Simulator) focus(window *simWindow) {
+	if s.active == window {
+		return
+	}
+
+	s.active = window
+	if window == nil {
+		s.emit(events.ActiveWindowEvent{}, events.ActiveWindowV2Event{})
+		return
+	}
+
+	window.workspace.lastWindow = window
+	s.focusHistory = slices.DeleteFunc(s.focusHistory, func(w *simWindow) bool {
+		return w == window
+	})
+	s.focusHistory = slices.Insert(s.focusHistory, 0, window)
+
+	s.emit(
+		events.ActiveWindowEvent{WindowClass: window.class, WindowTitle: window.title},
+		events.ActiveWindowV2Event{WindowAddress: eventAddress(window)},
+	)
+}
+
+func (s *Simulator) focusMonitor(monitor *simMonitor) {
+	if s.focused == monitor {
+		return
+	}
+
+	s.focused = monitor
+	s.emit(
+		events.FocusedMonitorEvent{MonitorName: monitor.name, WorkspaceName: monitor.workspace.name},
+		events.FocusedMonitorV2Event{MonitorName: monitor.name, WorkspaceID: monitor.workspace.id},
+	)
+}
+
+// resolveWindow returns the window a selector refers to. An empty selector refers
+// to the active window.
+// optionalWindow resolves the window of dispatchers such as togglefloating and
+// pin. Like Hyprland, they use the active window for an empty argument, "active"
+// or a single character, and treat anything else as a window selector.
+func (s *Simulator) optionalWindow(args string) *simWindow {
+	args = strings.TrimSpace(args)
+	if args == "" || args == "active" || len(args) == 1 {
+		return s.active
+	}
+
+	return s.resolveWindow(args)
+}
+
+func (s *Simulator) resolveWindow(selector string) *simWindow {
+	selector = strings.TrimSpace(selector)
+
+	switch selector {
+	case "", "activewindow":
+		return s.active
+	case "floating", "tiled":
+		for _, window := range s.windows {
+			if window.workspace == s.focused.workspace && window.floating == (selector == "floating") {
+				return window
+			}
+		}
+
+		return nil
+	}
+
+	// A selector without a known prefix is a class regex.
+	kind, value, ok := strings.Cut(selector, ":")
+	if !ok {
+		kind, value = "class", selector
+	}
+
+	switch kind {
+	case "address":
+		return s.windowByAddress(value)
+	case "pid":
+		pid, err := strconv.Atoi(value)
+		if err != nil {
+			return nil
+		}
+
+		for _, window := range s.windows {
+			if window.pid == pid {
+				return window
+			}
+		}
+	case "class", "initialclass", "title", "initialtitle":
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			return nil
+		}
+
+		for _, window := range s.windows {
+			field := window.class
+			if kind == "title" || kind == "initialtitle" {
+				field = window.title
+			}
+
+			if pattern.MatchString(field) {
+				return window
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Simulator) windowByAddress(address string) *simWindow {
+	address = "0x" + strings.TrimPrefix(address, "0x")
+	for _, window := range s.windows {
+		if window.address == address {
+			return window
+		}
+	}
+
+	return nil
+}
+
+func (s *Simulator) resolveMonitor(selector string) *simMonitor {
+	selector = strings.TrimSpace(selector)
+	if selector == "current" {
+		return s.focused
+	}
+
+	if strings.HasPrefix(selector, "+") || strings.HasPrefix(selector, "-") {
+		offset, err := strconv.Atoi(selector)
+		if err != nil {
+			return nil
+		}
+
+		index := slices.Index(s.monitors, s.focused) + offset
+		index = ((index % len(s.monitors)) + len(s.monitors)) % len(s.monitors)
+		return s.monitors[index]
+	}
+
+	description, byDescription := strings.CutPrefix(selector, "desc:")
+	id, err := strconv.Atoi(selector)
+	for _, monitor := range s.monitors {
+		switch {
+		case byDescription && monitor.description == description:
+			return monitor
+		case err == nil && monitor.id == id:
+			return monitor
+		case monitor.name == selector:
+			return monitor
+		}
+	}
+
+	return nil
+}
+
+func (s *Simulator) monitorJSON(monitor *simMonitor) hypr.Monitor {
+	return hypr.Monitor{
+		ID:              monitor.id,
+		Name:            monitor.name,
+		Description:     monitor.description,
+		Width:           monitor.width,
+		Height:          monitor.height,
+		RefreshRate:     60,
+		X:               monitor.x,
+		ActiveWorkspace: hypr.Workspace{Id: monitor.workspace.id, Name: monitor.workspace.name},
+		ReservedSpace:   slices.Clone(monitor.reserved),
+		Scale:           1,
+		Focused:         monitor == s.focused,
+		DpmsStatus:      true,
+		AvailableModes:  []string{fmt.Sprintf("%dx%d@60.00Hz", monitor.width, monitor.height)},
+	}
+}
+
+func (s *Simulator) workspaceJSON(workspace *simWorkspace) hypr.Workspace {
+	result := hypr.Workspace{
+		Id:        workspace.id,
+		Name:      workspace.name,
+		Monitor:   workspace.monitor.name,
+		MonitorID: workspace.monitor.id,
+	}
+
+	for _, window := range s.windows {
+		if window.workspace == workspace {
+			result.Windows++
+		}
+	}
+
+	if workspace.lastWindow != nil {
+		result.LastWindow = workspace.lastWindow.address
+		result.LastWindowTitle = workspace.lastWindow.title
+	}
+
+	return result
+}
+
+func (s *Simulator) windowJSON(window *simWindow) hypr.Window {
+	monitor := window.workspace.monitor
+	return hypr.Window{
+		Address:        window.address,
+		Mapped:         true,
+		At:             []int{monitor.x, 0},
+		Size:           []int{monitor.width, monitor.height},
+		Workspace:      hypr.Workspace{Id: window.workspace.id, Name: window.workspace.name},
+		Floating:       window.floating,
+		MonitorID:      monitor.id,
+		Class:          window.class,
+		Title:          window.title,
+		InitialClass:   window.class,
+		InitialTitle:   window.title,
+		Pid:            window.pid,
+		Pinned:         window.pinned,
+		Grouped:        make([]string, 0),
+		Tags:           make([]string, 0),
+		FocusHistoryID: s.focusHistoryID(window),
+	}
+}
+
+func (s *Simulator) focusHistoryID(window *simWindow) int {
+	if index := slices.Index(s.focusHistory, window); index >= 0 {
+		return index
+	}
+
+	return len(s.focusHistory)
+}
+
+// eventAddress returns the address of a window as events carry it, without the
+// "0x" prefix.
+func eventAddress(window *simWindow) string {
+	return strings.TrimPrefix(window.address, "0x")
+}
@@ -0,0 +1,204 @@
+package hyprtest
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jstncnnr/go-hyprland/hypr"
+	"github.com/jstncnnr/go-hyprland/hypr/commands"
+	events "github.com/jstncnnr/go-hyprland/hypr/event"
+)
+
+// subscribe connects an event client to the simulator and returns the raw lines
+// it receives.
+func subscribe(t *testing.T, sim *Simulator) <-chan string {
+	client, err := events.NewClient(events.WithInstance(sim.Instance()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	lines := make(chan string, 64)
+	client.RegisterListener(func(event events.Event) {
+		line, _ := events.Encode(event)
+		lines <- line
+	})
+	go func() { _ = client.Listen(ctx) }()
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer waitCancel()
+	if err := sim.WaitForEventClients(waitCtx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	return lines
+}
+
+func expectLines(t *testing.T, lines <-chan string, expected ...string) {
+	t.Helper()
+
+	for _, want := range expected {
+		select {
+		case line := <-lines:
+			if line != want {
+				t.Fatalf("expected %q, got %q", want, line)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", want)
+		}
+	}
+}
+
+func TestSimulatorOpenAndMoveWindow(t *testing.T) {
+	sim := NewSimulator(t)
+	lines := subscribe(t, sim)
+
+	window := sim.OpenWindow("kitty", "~")
+	address := window.Address[2:]
+
+	client, err := hypr.NewClient(hypr.WithInstance(sim.Instance()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Send(hypr.NewRequest().
+		MoveToWorkspaceSilent(commands.WorkspaceByID(3), commands.WindowByAddress(window.Address)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectLines(t, lines,
+		"openwindow>>"+address+",1,kitty,~",
+		"activewindow>>kitty,~",
+		"activewindowv2>>"+address,
+		"createworkspace>>3",
+		"createworkspacev2>>3,3",
+		"movewindow>>"+address+",3",
+		"movewindowv2>>"+address+",3,3",
+		"activewindow>>,",
+		"activewindowv2>>",
+	)
+
+	snapshot, err := client.GetSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if snapshot.ActiveWindow != nil || snapshot.ActiveWorkspace.Id != 1 {
+		t.Errorf("expected focus to stay on the empty workspace 1, got %+v on %+v", snapshot.ActiveWindow, snapshot.ActiveWorkspace)
+	}
+
+	if windows := snapshot.WindowsOn(3); len(windows) != 1 || windows[0].Address != window.Address {
+		t.Errorf("expected the window on workspace 3, got %v", windows)
+	}
+}
+
+func TestSimulatorSwitchWorkspace(t *testing.T) {
+	sim := NewSimulator(t)
+	first := sim.OpenWindow("kitty", "first")
+	lines := subscribe(t, sim)
+
+	client, err := hypr.NewClient(hypr.WithInstance(sim.Instance()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Send(hypr.NewRequest().Workspace(commands.WorkspaceByID(2))); err != nil {
+		t.Fatal(err)
+	}
+
+	second := sim.OpenWindow("firefox", "second")
+
+	if _, err := client.Send(hypr.NewRequest().FocusWindow(commands.WindowByClass("^kitty$"))); err != nil {
+		t.Fatal(err)
+	}
+
+	expectLines(t, lines,
+		"createworkspace>>2",
+		"createworkspacev2>>2,2",
+		"workspace>>2",
+		"workspacev2>>2,2",
+		"activewindow>>,",
+		"activewindowv2>>",
+		"openwindow>>"+second.Address[2:]+",2,firefox,second",
+		"activewindow>>firefox,second",
+		"activewindowv2>>"+second.Address[2:],
+		"workspace>>1",
+		"workspacev2>>1,1",
+		"activewindow>>kitty,first",
+		"activewindowv2>>"+first.Address[2:],
+	)
+
+	workspaces := sim.Workspaces()
+	if len(workspaces) != 2 || workspaces[1].Windows != 1 || workspaces[1].LastWindowTitle != "second" {
+		t.Errorf("unexpected workspaces %+v", workspaces)
+	}
+}
+
+func TestSimulatorCloseWindowFocusesPrevious(t *testing.T) {
+	sim := NewSimulator(t)
+	first := sim.OpenWindow("kitty", "first")
+	sim.OpenWindow("kitty", "second")
+
+	sim.Setenv(t)
+	if _, err := hypr.NewRequest().KillActive().Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	active, ok := sim.ActiveWindow()
+	if !ok || active.Address != first.Address {
+		t.Errorf("expected focus to return to %s, got %+v", first.Address, active)
+	}
+
+	if windows := sim.Windows(); len(windows) != 1 {
+		t.Errorf("expected one window left, got %v", windows)
+	}
+}
+
+func TestSimulatorReservedSpace(t *testing.T) {
+	sim := NewSimulator(t)
+	sim.AddMonitor("DP-2", 2560, 1440)
+	sim.Setenv(t)
+
+	if _, err := hypr.NewRequest().Keyword("monitor DP-2,addreserved,10,20,30,40").Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	monitors, err := hypr.GetMonitors()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(monitors) != 2 || !slices.Equal(monitors[1].ReservedSpace, []int{30, 10, 40, 20}) || monitors[1].X != 1920 {
+		t.Errorf("unexpected monitors %+v", monitors)
+	}
+
+	if monitors[1].ActiveWorkspace.Id != 2 {
+		t.Errorf("expected DP-2 to show workspace 2, got %v", monitors[1].ActiveWorkspace)
+	}
+}
+
+func TestSimulatorOptionalWindow(t *testing.T) {
+	sim := NewSimulator(t)
+	sim.OpenWindow("kitty", "~")
+	sim.Setenv(t)
+
+	if _, err := hypr.NewRequest().ToggleFloating(commands.WindowSelector{}).Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if active, ok := sim.ActiveWindow(); !ok || !active.Floating {
+		t.Errorf("expected the active window to float, got %+v", active)
+	}
+
+	// Anything else is a window selector, so a placeholder matches no window.
+	result, err := hypr.NewRequest().Dispatch("pin", "unused").Send()
+	if err == nil || result.Results[0].Response != "No such window found" {
+		t.Errorf("expected no window to match, got %q, %v", result.Results[0].Response, err)
+	}
+}
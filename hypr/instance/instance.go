@@ -0,0 +1,263 @@
+// Package instance finds running Hyprland instances and the sockets they expose.
+//
+// Every instance keeps its sockets and a hyprland.lock file in
+// $XDG_RUNTIME_DIR/hypr/$HYPRLAND_INSTANCE_SIGNATURE. The lock file holds the
+// PID of the compositor on the first line and its Wayland display on the second.
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+var (
+	// ErrNoInstance is returned when no running Hyprland instance matches.
+	ErrNoInstance = errors.New("no running Hyprland instance found. Please ensure Hyprland is running")
+
+	// ErrMultipleInstances is returned by Current when several instances are running
+	// and the environment does not say which one to use.
+	ErrMultipleInstances = errors.New("multiple Hyprland instances running. Please select one explicitly")
+)
+
+// SocketError is returned when a socket of an instance cannot be opened. It
+// matches ErrNoInstance with errors.Is when the socket is missing or nothing is
+// listening on it, which is what happens after Hyprland exits.
+type SocketError struct {
+	Path string
+	Err  error
+}
+
+func (e *SocketError) Error() string {
+	return fmt.Sprintf("unable to open hyprland socket %s: %v", e.Path, e.Err)
+}
+
+func (e *SocketError) Unwrap() error {
+	return e.Err
+}
+
+func (e *SocketError) Is(target error) bool {
+	return target == ErrNoInstance && (errors.Is(e.Err, syscall.ENOENT) || errors.Is(e.Err, syscall.ECONNREFUSED))
+}
+
+// Instance describes a single Hyprland instance.
+type Instance struct {
+	// Signature is the value Hyprland exports as HYPRLAND_INSTANCE_SIGNATURE.
+	Signature string
+
+	// PID of the compositor. 0 when the lock file could not be read.
+	PID int
+
+	// WaylandDisplay is the value Hyprland exports as WAYLAND_DISPLAY.
+	WaylandDisplay string
+
+	// RuntimeDir is the directory containing the hypr directory, usually XDG_RUNTIME_DIR.
+	RuntimeDir string
+}
+
+// Dir returns the directory holding the sockets of the instance.
+func (i Instance) Dir() string {
+	return filepath.Join(i.RuntimeDir, "hypr", i.Signature)
+}
+
+// SocketPath returns the path of the request socket (.socket.sock).
+func (i Instance) SocketPath() string {
+	return filepath.Join(i.Dir(), ".socket.sock")
+}
+
+// EventSocketPath returns the path of the event socket (.socket2.sock).
+func (i Instance) EventSocketPath() string {
+	return filepath.Join(i.Dir(), ".socket2.sock")
+}
+
+// Alive reports whether the compositor process of the instance is still running.
+func (i Instance) Alive() bool {
+	if i.PID <= 0 {
+		return false
+	}
+
+	err := syscall.Kill(i.PID, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// RuntimeDir returns the directory instances are looked up in: XDG_RUNTIME_DIR,
+// or /tmp when that is not set.
+func RuntimeDir() string {
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = "/tmp"
+	}
+
+	return runtimeDir
+}
+
+// List returns all running instances in RuntimeDir, sorted by signature.
+func List() ([]Instance, error) {
+	return ListIn(RuntimeDir())
+}
+
+// ListIn returns all running instances in runtimeDir, sorted by signature.
+// Directories left behind by instances that are no longer running are skipped.
+func ListIn(runtimeDir string) ([]Instance, error) {
+	entries, err := os.ReadDir(filepath.Join(runtimeDir, "hypr"))
+	if errors.Is(err, os.ErrNotExist) {
+		return make([]Instance, 0), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make([]Instance, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		instance, err := Read(runtimeDir, entry.Name())
+		if err != nil || !instance.Alive() {
+			continue
+		}
+
+		instances = append(instances, instance)
+	}
+
+	slices.SortFunc(instances, func(a, b Instance) int {
+		return strings.Compare(a.Signature, b.Signature)
+	})
+
+	return instances, nil
+}
+
+// Read reads the lock file of the instance with the given signature. It does not
+// check whether the instance is still running.
+func Read(runtimeDir string, signature string) (Instance, error) {
+	instance := Instance{
+		Signature:  signature,
+		RuntimeDir: runtimeDir,
+	}
+
+	data, err := os.ReadFile(filepath.Join(instance.Dir(), "hyprland.lock"))
+	if err != nil {
+		return instance, err
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	instance.PID, err = strconv.Atoi(strings.TrimSpace(lines[0]))
+	if err != nil {
+		return instance, fmt.Errorf("error parsing pid of instance %s: %v", signature, err)
+	}
+
+	if len(lines) > 1 {
+		instance.WaylandDisplay = strings.TrimSpace(lines[1])
+	}
+
+	return instance, nil
+}
+
+// FindBySignature returns the running instance with the given signature.
+func FindBySignature(signature string) (Instance, error) {
+	return FindBySignatureIn(RuntimeDir(), signature)
+}
+
+// FindBySignatureIn is like FindBySignature but looks for instances in runtimeDir.
+func FindBySignatureIn(runtimeDir string, signature string) (Instance, error) {
+	return find(runtimeDir, func(instance Instance) bool {
+		return instance.Signature == signature
+	})
+}
+
+// FindByPID returns the running instance whose compositor has the given PID.
+func FindByPID(pid int) (Instance, error) {
+	return FindByPIDIn(RuntimeDir(), pid)
+}
+
+// FindByPIDIn is like FindByPID but looks for instances in runtimeDir.
+func FindByPIDIn(runtimeDir string, pid int) (Instance, error) {
+	return find(runtimeDir, func(instance Instance) bool {
+		return instance.PID == pid
+	})
+}
+
+// FindByWaylandDisplay returns the running instance serving the given Wayland
+// display, for example "wayland-1". A full socket path is matched by its name.
+func FindByWaylandDisplay(display string) (Instance, error) {
+	return FindByWaylandDisplayIn(RuntimeDir(), display)
+}
+
+// FindByWaylandDisplayIn is like FindByWaylandDisplay but looks for instances in
+// runtimeDir.
+func FindByWaylandDisplayIn(runtimeDir string, display string) (Instance, error) {
+	display = filepath.Base(display)
+	return find(runtimeDir, func(instance Instance) bool {
+		return instance.WaylandDisplay == display
+	})
+}
+
+func find(runtimeDir string, match func(Instance) bool) (Instance, error) {
+	instances, err := ListIn(runtimeDir)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	for _, instance := range instances {
+		if match(instance) {
+			return instance, nil
+		}
+	}
+
+	return Instance{}, ErrNoInstance
+}
+
+// Current returns the instance the calling process belongs to, looked up in
+// RuntimeDir.
+func Current() (Instance, error) {
+	return CurrentIn(RuntimeDir())
+}
+
+// CurrentIn returns the instance the calling process belongs to, looked up in
+// runtimeDir.
+//
+// HYPRLAND_INSTANCE_SIGNATURE is used when it is set. Otherwise, the running
+// instance serving WAYLAND_DISPLAY is used, or the only running instance. This
+// lets tools started from SSH sessions, cron or systemd units without the
+// Hyprland environment still find the compositor.
+func CurrentIn(runtimeDir string) (Instance, error) {
+	if signature := os.Getenv("HYPRLAND_INSTANCE_SIGNATURE"); signature != "" {
+		instance, err := Read(runtimeDir, signature)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return Instance{}, err
+		}
+
+		return instance, nil
+	}
+
+	instances, err := ListIn(runtimeDir)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	if display := os.Getenv("WAYLAND_DISPLAY"); display != "" {
+		display = filepath.Base(display)
+		for _, instance := range instances {
+			if instance.WaylandDisplay == display {
+				return instance, nil
+			}
+		}
+	}
+
+	switch len(instances) {
+	case 0:
+		return Instance{}, ErrNoInstance
+	case 1:
+		return instances[0], nil
+	default:
+		return Instance{}, ErrMultipleInstances
+	}
+}
@@ -0,0 +1,113 @@
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// writeInstance creates an instance directory with a lock file in runtimeDir.
+func writeInstance(t *testing.T, runtimeDir string, signature string, pid int, display string) {
+	dir := filepath.Join(runtimeDir, "hypr", signature)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	lock := fmt.Sprintf("%d\n%s\n", pid, display)
+	if err := os.WriteFile(filepath.Join(dir, "hyprland.lock"), []byte(lock), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListInSkipsStaleInstances(t *testing.T) {
+	runtimeDir := t.TempDir()
+	writeInstance(t, runtimeDir, "live", os.Getpid(), "wayland-1")
+	writeInstance(t, runtimeDir, "stale", 1<<30, "wayland-2")
+
+	instances, err := ListIn(runtimeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Instance{Signature: "live", PID: os.Getpid(), WaylandDisplay: "wayland-1", RuntimeDir: runtimeDir}
+	if len(instances) != 1 || instances[0] != expected {
+		t.Errorf("expected [%v], got %v", expected, instances)
+	}
+}
+
+func TestCurrentIn(t *testing.T) {
+	runtimeDir := t.TempDir()
+	writeInstance(t, runtimeDir, "first", os.Getpid(), "wayland-1")
+
+	t.Setenv("HYPRLAND_INSTANCE_SIGNATURE", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	instance, err := CurrentIn(runtimeDir)
+	if err != nil || instance.Signature != "first" {
+		t.Errorf("expected the only instance, got %v, %v", instance, err)
+	}
+
+	writeInstance(t, runtimeDir, "second", os.Getpid(), "wayland-2")
+	if _, err := CurrentIn(runtimeDir); !errors.Is(err, ErrMultipleInstances) {
+		t.Errorf("expected ErrMultipleInstances, got %v", err)
+	}
+
+	t.Setenv("WAYLAND_DISPLAY", "wayland-2")
+	instance, err = CurrentIn(runtimeDir)
+	if err != nil || instance.Signature != "second" {
+		t.Errorf("expected instance for WAYLAND_DISPLAY, got %v, %v", instance, err)
+	}
+
+	t.Setenv("HYPRLAND_INSTANCE_SIGNATURE", "first")
+	instance, err = CurrentIn(runtimeDir)
+	if err != nil || instance.Signature != "first" {
+		t.Errorf("expected instance for HYPRLAND_INSTANCE_SIGNATURE, got %v, %v", instance, err)
+	}
+}
+
+func TestFindIn(t *testing.T) {
+	runtimeDir := t.TempDir()
+	writeInstance(t, runtimeDir, "first", os.Getpid(), "wayland-1")
+
+	if instance, err := FindBySignatureIn(runtimeDir, "first"); err != nil || instance.WaylandDisplay != "wayland-1" {
+		t.Errorf("expected the instance by signature, got %v, %v", instance, err)
+	}
+
+	if instance, err := FindByPIDIn(runtimeDir, os.Getpid()); err != nil || instance.Signature != "first" {
+		t.Errorf("expected the instance by pid, got %v, %v", instance, err)
+	}
+
+	if instance, err := FindByWaylandDisplayIn(runtimeDir, "/run/user/1000/wayland-1"); err != nil || instance.Signature != "first" {
+		t.Errorf("expected the instance by display, got %v, %v", instance, err)
+	}
+
+	if _, err := FindBySignatureIn(runtimeDir, "second"); !errors.Is(err, ErrNoInstance) {
+		t.Errorf("expected ErrNoInstance, got %v", err)
+	}
+}
+
+func TestCurrentInNoInstance(t *testing.T) {
+	t.Setenv("HYPRLAND_INSTANCE_SIGNATURE", "")
+
+	if _, err := CurrentIn(t.TempDir()); !errors.Is(err, ErrNoInstance) {
+		t.Errorf("expected ErrNoInstance, got %v", err)
+	}
+}
+
+func TestSocketErrorIsNoInstance(t *testing.T) {
+	_, err := net.Dial("unix", filepath.Join(t.TempDir(), ".socket.sock"))
+
+	var socketErr error = &SocketError{Path: "test", Err: err}
+	if !errors.Is(socketErr, ErrNoInstance) {
+		t.Errorf("expected %v to match ErrNoInstance", socketErr)
+	}
+
+	socketErr = &SocketError{Path: "test", Err: syscall.EACCES}
+	if errors.Is(socketErr, ErrNoInstance) {
+		t.Errorf("expected %v not to match ErrNoInstance", socketErr)
+	}
+}
@@ -0,0 +1,91 @@
+package instance
+
+import (
+	"context"
+	"time"
+)
+
+// ChangeType describes whether an instance appeared or disappeared.
+type ChangeType int
+
+const (
+	InstanceAdded   ChangeType = 0
+	InstanceRemoved ChangeType = 1
+)
+
+// Change is reported by Watch when an instance starts or stops.
+type Change struct {
+	Type     ChangeType
+	Instance Instance
+}
+
+// DefaultWatchInterval is the polling interval used by Watch when the given
+// interval is not positive.
+const DefaultWatchInterval = time.Second
+
+// Watch polls RuntimeDir every interval and reports instances that start or stop.
+// Instances already running when Watch is called are reported as added first.
+// The channel is closed once ctx is done. An interval of 0 or less uses
+// DefaultWatchInterval.
+func Watch(ctx context.Context, interval time.Duration) <-chan Change {
+	return WatchIn(ctx, RuntimeDir(), interval)
+}
+
+// WatchIn is like Watch but looks for instances in runtimeDir.
+func WatchIn(ctx context.Context, runtimeDir string, interval time.Duration) <-chan Change {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	changes := make(chan Change)
+
+	go func() {
+		defer close(changes)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		known := make(map[string]Instance)
+		for {
+			instances, err := ListIn(runtimeDir)
+			if err == nil {
+				current := make(map[string]Instance)
+				for _, instance := range instances {
+					current[instance.Signature] = instance
+					if _, ok := known[instance.Signature]; !ok {
+						if !send(ctx, changes, Change{Type: InstanceAdded, Instance: instance}) {
+							return
+						}
+					}
+				}
+
+				for signature, instance := range known {
+					if _, ok := current[signature]; !ok {
+						if !send(ctx, changes, Change{Type: InstanceRemoved, Instance: instance}) {
+							return
+						}
+					}
+				}
+
+				known = current
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return changes
+}
+
+func send(ctx context.Context, changes chan<- Change, change Change) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case changes <- change:
+		return true
+	}
+}
@@ -0,0 +1,71 @@
+package instance
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchIn(t *testing.T) {
+	runtimeDir := t.TempDir()
+	writeInstance(t, runtimeDir, "first", os.Getpid(), "wayland-1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := WatchIn(ctx, runtimeDir, 10*time.Millisecond)
+
+	expect := func(changeType ChangeType, signature string) {
+		t.Helper()
+
+		select {
+		case change := <-changes:
+			if change.Type != changeType || change.Instance.Signature != signature {
+				t.Fatalf("expected change %d for %s, got %+v", changeType, signature, change)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for change %d for %s", changeType, signature)
+		}
+	}
+
+	// Instances running before the watch starts are reported as added.
+	expect(InstanceAdded, "first")
+
+	writeInstance(t, runtimeDir, "second", os.Getpid(), "wayland-2")
+	expect(InstanceAdded, "second")
+
+	if err := os.RemoveAll(filepath.Join(runtimeDir, "hypr", "first")); err != nil {
+		t.Fatal(err)
+	}
+	expect(InstanceRemoved, "first")
+
+	cancel()
+
+	select {
+	case change, ok := <-changes:
+		if ok {
+			t.Errorf("expected the channel to be closed, got %+v", change)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("expected the channel to be closed once ctx is done")
+	}
+}
+
+func TestWatchInDefaultInterval(t *testing.T) {
+	runtimeDir := t.TempDir()
+	writeInstance(t, runtimeDir, "first", os.Getpid(), "wayland-1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	select {
+	case change := <-WatchIn(ctx, runtimeDir, 0):
+		if change.Type != InstanceAdded || change.Instance.Signature != "first" {
+			t.Errorf("expected the running instance to be added, got %+v", change)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the running instance")
+	}
+}
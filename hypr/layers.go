@@ -0,0 +1,89 @@
+package hypr
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PlacedLayer is a layer surface along with the monitor and level it is on.
+type PlacedLayer struct {
+	Layer
+
+	// Monitor is the name of the monitor.
+	Monitor string
+
+	Level LayerLevel
+}
+
+func (l LayerLevel) String() string {
+	switch l {
+	case LayerBackground:
+		return "background"
+	case LayerBottom:
+		return "bottom"
+	case LayerTop:
+		return "top"
+	case LayerOverlay:
+		return "overlay"
+	default:
+		return fmt.Sprintf("LayerLevel(%d)", int(l))
+	}
+}
+
+// Layers returns every layer surface, ordered by monitor name and then from the
+// background level up.
+func (t LayerTable) Layers() []PlacedLayer {
+	layers := make([]PlacedLayer, 0)
+
+	monitors := make([]string, 0, len(t))
+	for monitor := range t {
+		monitors = append(monitors, monitor)
+	}
+	slices.Sort(monitors)
+
+	for _, monitor := range monitors {
+		levels := t[monitor].Levels
+
+		keys := make([]LayerLevel, 0, len(levels))
+		for level := range levels {
+			keys = append(keys, level)
+		}
+		slices.Sort(keys)
+
+		for _, level := range keys {
+			for _, layer := range levels[level] {
+				layers = append(layers, PlacedLayer{Layer: layer, Monitor: monitor, Level: level})
+			}
+		}
+	}
+
+	return layers
+}
+
+// LayersWithNamespace returns the layer surfaces with the given namespace, the
+// name reported by OpenLayerEvent and CloseLayerEvent.
+func (t LayerTable) LayersWithNamespace(namespace string) []PlacedLayer {
+	return slices.DeleteFunc(t.Layers(), func(layer PlacedLayer) bool {
+		return layer.Namespace != namespace
+	})
+}
+
+// HasNamespace reports whether a layer surface with the given namespace is
+// mapped, such as a bar or notification daemon.
+func (t LayerTable) HasNamespace(namespace string) bool {
+	return len(t.LayersWithNamespace(namespace)) > 0
+}
+
+// Layer returns the layer surface with the given address, with or without the
+// "0x" prefix.
+func (t LayerTable) Layer(address string) (PlacedLayer, bool) {
+	address = strings.TrimPrefix(address, "0x")
+	for _, layer := range t.Layers() {
+		if strings.TrimPrefix(layer.Address, "0x") == address {
+			return layer, true
+		}
+	}
+
+	return PlacedLayer{}, false
+}
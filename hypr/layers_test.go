@@ -0,0 +1,54 @@
+package hypr
+
+import "testing"
+
+func TestGetLayers(t *testing.T) {
+	reply := `{
+		"DP-2":{"levels":{"2":[{"address":"0x5","namespace":"waybar","w":1920,"h":30}]}},
+		"DP-1":{"levels":{
+			"3":[{"address":"0x4","namespace":"notifications"}],
+			"0":[{"address":"0x3","namespace":"wallpaper"}],
+			"2":[{"address":"0x2","namespace":"waybar"}]
+		}}
+	}`
+
+	c, err := NewClient(WithSocketPath(serveOnce(t, reply)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layers, err := c.GetLayers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addresses := make([]string, 0)
+	for _, layer := range layers.Layers() {
+		addresses = append(addresses, layer.Address)
+	}
+
+	expected := []string{"0x3", "0x2", "0x4", "0x5"}
+	if len(addresses) != len(expected) {
+		t.Fatalf("expected layers %v, got %v", expected, addresses)
+	}
+
+	for i := range expected {
+		if addresses[i] != expected[i] {
+			t.Errorf("expected layers %v, got %v", expected, addresses)
+			break
+		}
+	}
+
+	bars := layers.LayersWithNamespace("waybar")
+	if len(bars) != 2 || bars[1].Monitor != "DP-2" || bars[1].Level != LayerTop || bars[1].Height != 30 {
+		t.Errorf("expected a bar on each monitor, got %+v", bars)
+	}
+
+	if layers.HasNamespace("rofi") || !layers.HasNamespace("notifications") {
+		t.Error("expected only the notification daemon to be mapped")
+	}
+
+	if layer, ok := layers.Layer("4"); !ok || layer.Level.String() != "overlay" {
+		t.Errorf("expected layer 0x4 on the overlay level, got %+v", layer)
+	}
+}
@@ -1,147 +1,335 @@
 package hypr
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"github.com/jstncnnr/go-hyprland/hypr/commands"
+)
 
+// GetMonitors returns all monitors using a client created with NewClient.
 func GetMonitors() ([]Monitor, error) {
-	c, err := newClient()
+	return GetMonitorsContext(context.Background())
+}
+
+// GetMonitorsContext is like GetMonitors but honors the deadline and cancellation of ctx.
+func GetMonitorsContext(ctx context.Context) ([]Monitor, error) {
+	c, err := NewClient()
 	if err != nil {
 		return nil, err
 	}
 
-	defer func(c *client) {
-		_ = c.Close()
-	}(c)
+	return c.GetMonitorsContext(ctx)
+}
 
-	resp, err := c.SendJSONRequest("monitors")
-	if err != nil {
-		return nil, err
-	}
+// GetWorkspaces returns all workspaces using a client created with NewClient.
+func GetWorkspaces() ([]Workspace, error) {
+	return GetWorkspacesContext(context.Background())
+}
 
-	monitors := make([]Monitor, 0)
-	err = json.Unmarshal(resp, &monitors)
+// GetWorkspacesContext is like GetWorkspaces but honors the deadline and cancellation of ctx.
+func GetWorkspacesContext(ctx context.Context) ([]Workspace, error) {
+	c, err := NewClient()
 	if err != nil {
 		return nil, err
 	}
 
-	return monitors, nil
+	return c.GetWorkspacesContext(ctx)
 }
 
-func GetWorkspaces() ([]Workspace, error) {
-	c, err := newClient()
+// GetWindows returns all windows using a client created with NewClient.
+func GetWindows() ([]Window, error) {
+	return GetWindowsContext(context.Background())
+}
+
+// GetWindowsContext is like GetWindows but honors the deadline and cancellation of ctx.
+func GetWindowsContext(ctx context.Context) ([]Window, error) {
+	c, err := NewClient()
 	if err != nil {
 		return nil, err
 	}
 
-	defer func(c *client) {
-		_ = c.Close()
-	}(c)
+	return c.GetWindowsContext(ctx)
+}
 
-	resp, err := c.SendJSONRequest("workspaces")
-	if err != nil {
-		return nil, err
-	}
+// GetActiveWorkspace returns the active workspace using a client created with NewClient.
+func GetActiveWorkspace() (*Workspace, error) {
+	return GetActiveWorkspaceContext(context.Background())
+}
 
-	workspaces := make([]Workspace, 0)
-	err = json.Unmarshal(resp, &workspaces)
+// GetActiveWorkspaceContext is like GetActiveWorkspace but honors the deadline and cancellation of ctx.
+func GetActiveWorkspaceContext(ctx context.Context) (*Workspace, error) {
+	c, err := NewClient()
 	if err != nil {
 		return nil, err
 	}
 
-	return workspaces, nil
+	return c.GetActiveWorkspaceContext(ctx)
 }
 
-func GetWindows() ([]Window, error) {
-	c, err := newClient()
+// GetActiveWindow returns the active window using a client created with NewClient.
+func GetActiveWindow() (*Window, error) {
+	return GetActiveWindowContext(context.Background())
+}
+
+// GetActiveWindowContext is like GetActiveWindow but honors the deadline and cancellation of ctx.
+func GetActiveWindowContext(ctx context.Context) (*Window, error) {
+	c, err := NewClient()
 	if err != nil {
 		return nil, err
 	}
 
-	defer func(c *client) {
-		_ = c.Close()
-	}(c)
+	return c.GetActiveWindowContext(ctx)
+}
+
+// GetDeviceTable returns all input devices using a client created with NewClient.
+func GetDeviceTable() (*DeviceTable, error) {
+	return GetDeviceTableContext(context.Background())
+}
 
-	resp, err := c.SendJSONRequest("clients")
+// GetDeviceTableContext is like GetDeviceTable but honors the deadline and cancellation of ctx.
+func GetDeviceTableContext(ctx context.Context) (*DeviceTable, error) {
+	c, err := NewClient()
 	if err != nil {
 		return nil, err
 	}
 
-	windows := make([]Window, 0)
-	err = json.Unmarshal(resp, &windows)
+	return c.GetDeviceTableContext(ctx)
+}
+
+// GetLayers returns the layer surfaces of all monitors using a client created with NewClient.
+func GetLayers() (LayerTable, error) {
+	return GetLayersContext(context.Background())
+}
+
+// GetLayersContext is like GetLayers but honors the deadline and cancellation of ctx.
+func GetLayersContext(ctx context.Context) (LayerTable, error) {
+	c, err := NewClient()
 	if err != nil {
 		return nil, err
 	}
 
-	return windows, nil
+	return c.GetLayersContext(ctx)
 }
 
-func GetActiveWorkspace() (*Workspace, error) {
-	c, err := newClient()
+// GetBinds returns all keybinds using a client created with NewClient.
+func GetBinds() ([]Bind, error) {
+	return GetBindsContext(context.Background())
+}
+
+// GetBindsContext is like GetBinds but honors the deadline and cancellation of ctx.
+func GetBindsContext(ctx context.Context) ([]Bind, error) {
+	c, err := NewClient()
 	if err != nil {
 		return nil, err
 	}
 
-	defer func(c *client) {
-		_ = c.Close()
-	}(c)
+	return c.GetBindsContext(ctx)
+}
+
+// GetMonitors returns all monitors.
+func (c *Client) GetMonitors() ([]Monitor, error) {
+	return c.GetMonitorsContext(context.Background())
+}
+
+// GetMonitorsContext is like GetMonitors but honors the deadline and cancellation of ctx.
+func (c *Client) GetMonitorsContext(ctx context.Context) ([]Monitor, error) {
+	return sendQuery(ctx, c, MonitorsQuery())
+}
 
-	resp, err := c.SendJSONRequest("activeworkspace")
+// GetWorkspaces returns all workspaces.
+func (c *Client) GetWorkspaces() ([]Workspace, error) {
+	return c.GetWorkspacesContext(context.Background())
+}
+
+// GetWorkspacesContext is like GetWorkspaces but honors the deadline and cancellation of ctx.
+func (c *Client) GetWorkspacesContext(ctx context.Context) ([]Workspace, error) {
+	return sendQuery(ctx, c, WorkspacesQuery())
+}
+
+// GetWindows returns all windows.
+func (c *Client) GetWindows() ([]Window, error) {
+	return c.GetWindowsContext(context.Background())
+}
+
+// GetWindowsContext is like GetWindows but honors the deadline and cancellation of ctx.
+func (c *Client) GetWindowsContext(ctx context.Context) ([]Window, error) {
+	return sendQuery(ctx, c, WindowsQuery())
+}
+
+// GetActiveWorkspace returns the active workspace.
+func (c *Client) GetActiveWorkspace() (*Workspace, error) {
+	return c.GetActiveWorkspaceContext(context.Background())
+}
+
+// GetActiveWorkspaceContext is like GetActiveWorkspace but honors the deadline and cancellation of ctx.
+func (c *Client) GetActiveWorkspaceContext(ctx context.Context) (*Workspace, error) {
+	workspace, err := sendQuery(ctx, c, ActiveWorkspaceQuery())
 	if err != nil {
 		return nil, err
 	}
 
-	workspace := new(Workspace)
-	err = json.Unmarshal(resp, workspace)
+	return &workspace, nil
+}
+
+// GetActiveWindow returns the active window.
+func (c *Client) GetActiveWindow() (*Window, error) {
+	return c.GetActiveWindowContext(context.Background())
+}
+
+// GetActiveWindowContext is like GetActiveWindow but honors the deadline and cancellation of ctx.
+func (c *Client) GetActiveWindowContext(ctx context.Context) (*Window, error) {
+	window, err := sendQuery(ctx, c, ActiveWindowQuery())
 	if err != nil {
 		return nil, err
 	}
 
-	return workspace, nil
+	return &window, nil
 }
 
-func GetActiveWindow() (*Window, error) {
-	c, err := newClient()
+// GetDeviceTable returns all input devices.
+func (c *Client) GetDeviceTable() (*DeviceTable, error) {
+	return c.GetDeviceTableContext(context.Background())
+}
+
+// GetDeviceTableContext is like GetDeviceTable but honors the deadline and cancellation of ctx.
+func (c *Client) GetDeviceTableContext(ctx context.Context) (*DeviceTable, error) {
+	devices, err := sendQuery(ctx, c, DevicesQuery())
 	if err != nil {
 		return nil, err
 	}
 
-	defer func(c *client) {
-		_ = c.Close()
-	}(c)
+	return &devices, nil
+}
+
+// GetLayers returns the layer surfaces of all monitors.
+func (c *Client) GetLayers() (LayerTable, error) {
+	return c.GetLayersContext(context.Background())
+}
+
+// GetLayersContext is like GetLayers but honors the deadline and cancellation of ctx.
+func (c *Client) GetLayersContext(ctx context.Context) (LayerTable, error) {
+	return sendQuery(ctx, c, LayersQuery())
+}
+
+// GetBinds returns all keybinds.
+func (c *Client) GetBinds() ([]Bind, error) {
+	return c.GetBindsContext(context.Background())
+}
+
+// GetBindsContext is like GetBinds but honors the deadline and cancellation of ctx.
+func (c *Client) GetBindsContext(ctx context.Context) ([]Bind, error) {
+	return sendQuery(ctx, c, BindsQuery())
+}
+
+// Querier is a command whose reply is a JSON document rather than "ok". Queries
+// added to a Request are decoded into the Value of their CommandResult.
+type Querier interface {
+	commands.Command
+	Decode(data []byte) (any, error)
+}
+
+// Query is a typed query of Hyprland state, such as MonitorsQuery. It can be sent
+// on its own with the Get functions or added to a Request, which sends it in the
+// same batch as the other commands.
+type Query[T any] struct {
+	// Command is the hyprctl command, without the "j/" prefix.
+	Command string
+
+	into *T
+}
+
+// NewQuery creates a query for command whose JSON reply decodes into T.
+func NewQuery[T any](command string) Query[T] {
+	return Query[T]{Command: command}
+}
+
+// MonitorsQuery queries all monitors.
+func MonitorsQuery() Query[[]Monitor] {
+	return NewQuery[[]Monitor]("monitors")
+}
+
+// WorkspacesQuery queries all workspaces.
+func WorkspacesQuery() Query[[]Workspace] {
+	return NewQuery[[]Workspace]("workspaces")
+}
 
-	resp, err := c.SendJSONRequest("activewindow")
+// WindowsQuery queries all windows.
+func WindowsQuery() Query[[]Window] {
+	return NewQuery[[]Window]("clients")
+}
+
+// ActiveWorkspaceQuery queries the active workspace.
+func ActiveWorkspaceQuery() Query[Workspace] {
+	return NewQuery[Workspace]("activeworkspace")
+}
+
+// ActiveWindowQuery queries the active window.
+func ActiveWindowQuery() Query[Window] {
+	return NewQuery[Window]("activewindow")
+}
+
+// DevicesQuery queries all input devices.
+func DevicesQuery() Query[DeviceTable] {
+	return NewQuery[DeviceTable]("devices")
+}
+
+// LayersQuery queries the layer surfaces of all monitors.
+func LayersQuery() Query[LayerTable] {
+	return NewQuery[LayerTable]("layers")
+}
+
+// BindsQuery queries all keybinds.
+func BindsQuery() Query[[]Bind] {
+	return NewQuery[[]Bind]("binds")
+}
+
+// Into returns a copy of the query that also stores the decoded reply in dst when
+// it is sent as part of a Request.
+func (q Query[T]) Into(dst *T) Query[T] {
+	q.into = dst
+	return q
+}
+
+func (q Query[T]) String() string {
+	return "j/" + q.Command
+}
+
+// Decode decodes a reply to the query into a T, failing with a *DecodeError.
+func (q Query[T]) Decode(data []byte) (any, error) {
+	value, err := q.decode(data)
 	if err != nil {
 		return nil, err
 	}
 
-	window := new(Window)
-	err = json.Unmarshal(resp, window)
-	if err != nil {
-		return nil, err
+	if q.into != nil {
+		*q.into = value
 	}
 
-	return window, nil
+	return value, nil
 }
 
-func GetDeviceTable() (*DeviceTable, error) {
-	c, err := newClient()
-	if err != nil {
-		return nil, err
+func (q Query[T]) decode(data []byte) (T, error) {
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return value, &DecodeError{Command: q.Command, Raw: data, Err: err}
 	}
 
-	defer func(c *client) {
-		_ = c.Close()
-	}(c)
+	return value, nil
+}
 
-	resp, err := c.SendJSONRequest("devices")
+// sendQuery sends a single query on its own connection. A reply that cannot be
+// decoded fails with a *CommandError, like a query sent in a Request.
+func sendQuery[T any](ctx context.Context, c *Client, q Query[T]) (T, error) {
+	resp, err := c.SendRequestContext(ctx, q.String())
 	if err != nil {
-		return nil, err
+		var zero T
+		return zero, err
 	}
 
-	devices := new(DeviceTable)
-	err = json.Unmarshal(resp, devices)
+	value, err := q.decode(resp)
 	if err != nil {
-		return nil, err
+		return value, &CommandError{Command: q, Response: string(resp), Err: err}
 	}
 
-	return devices, nil
+	return value, nil
 }
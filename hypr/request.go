@@ -1,7 +1,8 @@
 package hypr
 
 import (
-	"errors"
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/jstncnnr/go-hyprland/hypr/commands"
 	"strings"
@@ -9,6 +10,9 @@ import (
 
 type Request struct {
 	commands []commands.Command
+
+	// err is the first error from building the request, returned by Send.
+	err error
 }
 
 func NewRequest() *Request {
@@ -17,11 +21,32 @@ func NewRequest() *Request {
 	}
 }
 
+// AddCommand adds a command to the request. A command implementing
+// commands.Validator that fails validation makes Send return the error without
+// sending anything.
 func (req *Request) AddCommand(command commands.Command) *Request {
+	if validator, ok := command.(commands.Validator); ok {
+		if err := validator.Validate(); err != nil {
+			req.setErr(fmt.Errorf("invalid command %T: %w", command, err))
+		}
+	}
+
 	req.commands = append(req.commands, command)
 	return req
 }
 
+func (req *Request) setErr(err error) {
+	if req.err == nil {
+		req.err = err
+	}
+}
+
+// Query adds a query to the request. Its decoded reply is stored in the Value of
+// its CommandResult, and in the destination of Query.Into when set.
+func (req *Request) Query(query Querier) *Request {
+	return req.AddCommand(query)
+}
+
 // Dispatch issues a dispatch to call a keybind dispatcher with an argument.
 // See https://wiki.hyprland.org/Configuring/Dispatchers for a list of dispatchers.
 func (req *Request) Dispatch(dispatcher string, args ...string) *Request {
@@ -145,18 +170,61 @@ func (req *Request) DismissNotify(count int) *Request {
 	})
 }
 
-func (req *Request) Send() error {
-	c, err := newClient()
+// EmitEvent emits a custom event that listeners on the event socket receive as an
+// events.CustomEvent with the given data.
+//
+// Data may not contain newlines or semicolons. Use EmitJSONEvent to send
+// arbitrary values.
+func (req *Request) EmitEvent(data string) *Request {
+	return req.AddCommand(commands.EventCommand{
+		Data: data,
+	})
+}
+
+// EmitJSONEvent emits a custom event carrying payload encoded as JSON. Listeners
+// decode it with events.CustomEvent.Decode.
+func (req *Request) EmitJSONEvent(payload any) *Request {
+	data, err := json.Marshal(payload)
 	if err != nil {
-		return err
+		req.setErr(fmt.Errorf("error encoding event payload: %w", err))
+		return req
 	}
 
-	defer func(c *client) {
-		_ = c.Close()
-	}(c)
+	// Semicolons can only appear inside JSON strings, where the escaped form
+	// decodes to the same value.
+	return req.EmitEvent(strings.ReplaceAll(string(data), ";", `\u003b`))
+}
+
+// Send sends the request using a client created with NewClient.
+func (req *Request) Send() (BatchResult, error) {
+	return req.SendContext(context.Background())
+}
+
+// SendContext is like Send but honors the deadline and cancellation of ctx.
+func (req *Request) SendContext(ctx context.Context) (BatchResult, error) {
+	c, err := NewClient()
+	if err != nil {
+		return BatchResult{}, err
+	}
+
+	return c.SendContext(ctx, req)
+}
+
+// Send sends all commands in the request, batching them when there is more than one.
+// The result holds the reply to every command. When any command fails, the returned
+// error joins their *CommandError values and the result is still complete.
+func (c *Client) Send(req *Request) (BatchResult, error) {
+	return c.SendContext(context.Background(), req)
+}
+
+// SendContext is like Send but honors the deadline and cancellation of ctx.
+func (c *Client) SendContext(ctx context.Context, req *Request) (BatchResult, error) {
+	if req.err != nil {
+		return BatchResult{}, req.err
+	}
 
 	if len(req.commands) == 0 {
-		return errors.New("request has no commands")
+		return BatchResult{}, ErrEmptyRequest
 	}
 
 	var request = ""
@@ -172,22 +240,16 @@ func (req *Request) Send() error {
 		request += command.String()
 	}
 
-	resp, err := c.SendRequest(request)
+	resp, err := c.SendRequestContext(ctx, request)
 	if err != nil {
-		return err
+		return BatchResult{}, err
 	}
 
-	err = nil
-	responses := strings.Split(string(resp), "\n\n\n")
-	for index, response := range responses {
-		if response != "ok" {
-			if err == nil {
-				err = fmt.Errorf("error running command %T: %v\n", req.commands[index], response)
-			} else {
-				err = fmt.Errorf("%verror running command %T: %v\n", err, req.commands[index], response)
-			}
-		}
+	responses := []string{string(resp)}
+	if len(req.commands) > 1 {
+		responses = strings.Split(string(resp), "\n\n\n")
 	}
 
-	return err
+	result := newBatchResult(req.commands, responses)
+	return result, result.Err()
 }
@@ -0,0 +1,130 @@
+package hypr
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEmitJSONEvent(t *testing.T) {
+	payload := map[string]string{"text": "a;b\nc"}
+
+	req := NewRequest().EmitJSONEvent(payload)
+	if req.err != nil {
+		t.Fatalf("EmitJSONEvent: %v", req.err)
+	}
+
+	command := req.commands[0].String()
+	if strings.ContainsAny(command, ";\n") {
+		t.Errorf("command contains unsafe characters: %q", command)
+	}
+
+	var decoded map[string]string
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(command, "dispatch event ")), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded["text"] != payload["text"] {
+		t.Errorf("expected %q, got %q", payload["text"], decoded["text"])
+	}
+}
+
+func TestEmitEventRejectsUnsafeData(t *testing.T) {
+	c, err := NewClient(WithSocketPath("/nonexistent"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Send(NewRequest().EmitEvent("a;b")); err == nil || !strings.Contains(err.Error(), "invalid command") {
+		t.Errorf("expected an invalid command error, got %v", err)
+	}
+}
+
+func TestSendBatchResult(t *testing.T) {
+	c, err := NewClient(WithSocketPath(serveOnce(t, "ok\n\n\nInvalid dispatcher")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := c.Send(NewRequest().Reload().Dispatch("nope").Kill())
+
+	var commandErr *CommandError
+	if !errors.As(err, &commandErr) || commandErr.Index != 1 || commandErr.Response != "Invalid dispatcher" {
+		t.Fatalf("expected a CommandError for command 1, got %v", err)
+	}
+
+	if len(result.Results) != 3 || !result.Results[0].OK() || result.Results[1].OK() {
+		t.Fatalf("unexpected results %+v", result.Results)
+	}
+
+	if !errors.Is(result.Results[2].Err, ErrMissingResponse) {
+		t.Errorf("expected ErrMissingResponse for command 2, got %v", result.Results[2].Err)
+	}
+
+	retry := result.Retry()
+	if len(retry.commands) != 2 || retry.commands[0] != result.Results[1].Command {
+		t.Errorf("expected a retry of the failed commands, got %v", retry.commands)
+	}
+}
+
+func TestSendMixedBatch(t *testing.T) {
+	reply := `[{"id":0,"name":"DP-1"}]` + "\n\n\nok\n\n\n" + `{"address":"0x1","class":"kitty"}`
+	c, err := NewClient(WithSocketPath(serveOnce(t, reply)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var window Window
+	result, err := c.Send(NewRequest().
+		Query(MonitorsQuery()).
+		Reload().
+		Query(ActiveWindowQuery().Into(&window)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	monitors, ok := result.Results[0].Value.([]Monitor)
+	if !ok || len(monitors) != 1 || monitors[0].Name != "DP-1" {
+		t.Errorf("expected the decoded monitors, got %#v", result.Results[0].Value)
+	}
+
+	if window.Class != "kitty" {
+		t.Errorf("expected the active window to be stored, got %+v", window)
+	}
+}
+
+func TestRetryAfterTransportError(t *testing.T) {
+	c, err := NewClient(WithSocketPath("/nonexistent"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := c.Send(NewRequest().Reload())
+	if err == nil {
+		t.Fatal("expected a socket error")
+	}
+
+	if _, err := c.Send(result.Retry()); !errors.Is(err, ErrEmptyRequest) {
+		t.Errorf("expected ErrEmptyRequest retrying without failed commands, got %v", err)
+	}
+}
+
+func TestSendRejectedQuery(t *testing.T) {
+	c, err := NewClient(WithSocketPath(serveOnce(t, "ok\n\n\nunknown request")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Send(NewRequest().Reload().Query(MonitorsQuery()))
+
+	var commandErr *CommandError
+	if !errors.As(err, &commandErr) || commandErr.Index != 1 || commandErr.Response != "unknown request" {
+		t.Fatalf("expected a CommandError for command 1, got %v", err)
+	}
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.Command != "monitors" {
+		t.Errorf("expected the CommandError to wrap a DecodeError, got %v", err)
+	}
+}
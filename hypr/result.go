@@ -0,0 +1,104 @@
+package hypr
+
+import (
+	"errors"
+	"github.com/jstncnnr/go-hyprland/hypr/commands"
+)
+
+// CommandResult is the outcome of a single command of a request.
+type CommandResult struct {
+	// Index of the command in the request.
+	Index int
+
+	Command commands.Command
+
+	// Response is the reply Hyprland sent for the command.
+	Response string
+
+	// Value is the decoded reply of a Querier.
+	Value any
+
+	// Err is a *CommandError when the command failed. It wraps a *DecodeError when
+	// the reply to a query could not be decoded.
+	Err error
+}
+
+// OK reports whether the command succeeded.
+func (r CommandResult) OK() bool {
+	return r.Err == nil
+}
+
+// BatchResult holds the results of the commands of a request, in the order they
+// were added.
+type BatchResult struct {
+	Results []CommandResult
+}
+
+// Err returns the errors of all failed commands joined with errors.Join, or nil
+// when every command succeeded.
+func (b BatchResult) Err() error {
+	errs := make([]error, 0)
+	for _, result := range b.Results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Failed returns the results of the commands that failed.
+func (b BatchResult) Failed() []CommandResult {
+	failed := make([]CommandResult, 0)
+	for _, result := range b.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+
+	return failed
+}
+
+// Retry returns a new request containing only the commands that failed. When no
+// command failed, including when the request never reached Hyprland, the request
+// is empty and sending it fails with ErrEmptyRequest.
+func (b BatchResult) Retry() *Request {
+	req := NewRequest()
+	for _, result := range b.Failed() {
+		req.AddCommand(result.Command)
+	}
+
+	return req
+}
+
+// newBatchResult matches the replies of a request to its commands and decodes the
+// replies to queries. Commands without a reply fail with ErrMissingResponse.
+func newBatchResult(cmds []commands.Command, responses []string) BatchResult {
+	results := make([]CommandResult, len(cmds))
+	for index, command := range cmds {
+		result := CommandResult{Index: index, Command: command}
+		if index < len(responses) {
+			result.Response = responses[index]
+		}
+
+		query, isQuery := command.(Querier)
+		switch {
+		case index >= len(responses):
+			result.Err = &CommandError{Index: index, Command: command, Err: ErrMissingResponse}
+		case isQuery:
+			value, err := query.Decode([]byte(result.Response))
+			if err != nil {
+				result.Err = &CommandError{Index: index, Command: command, Response: result.Response, Err: err}
+				break
+			}
+
+			result.Value = value
+		case result.Response != "ok":
+			result.Err = &CommandError{Index: index, Command: command, Response: result.Response}
+		}
+
+		results[index] = result
+	}
+
+	return BatchResult{Results: results}
+}
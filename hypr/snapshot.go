@@ -0,0 +1,135 @@
+package hypr
+
+import (
+	"context"
+	"strings"
+)
+
+// Snapshot is the state of Hyprland at a single point in time. All parts are
+// fetched in one batched request, so they agree with each other.
+type Snapshot struct {
+	Monitors        []Monitor
+	Workspaces      []Workspace
+	Windows         []Window
+	ActiveWorkspace Workspace
+
+	// ActiveWindow is nil when no window has focus.
+	ActiveWindow *Window
+
+	Layers  LayerTable
+	Devices DeviceTable
+}
+
+// GetSnapshot returns the current state using a client created with NewClient.
+func GetSnapshot() (*Snapshot, error) {
+	return GetSnapshotContext(context.Background())
+}
+
+// GetSnapshotContext is like GetSnapshot but honors the deadline and cancellation of ctx.
+func GetSnapshotContext(ctx context.Context) (*Snapshot, error) {
+	c, err := NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetSnapshotContext(ctx)
+}
+
+// GetSnapshot returns the current state.
+func (c *Client) GetSnapshot() (*Snapshot, error) {
+	return c.GetSnapshotContext(context.Background())
+}
+
+// GetSnapshotContext is like GetSnapshot but honors the deadline and cancellation of ctx.
+func (c *Client) GetSnapshotContext(ctx context.Context) (*Snapshot, error) {
+	snapshot := new(Snapshot)
+	activeWindow := new(Window)
+
+	_, err := c.SendContext(ctx, NewRequest().
+		Query(MonitorsQuery().Into(&snapshot.Monitors)).
+		Query(WorkspacesQuery().Into(&snapshot.Workspaces)).
+		Query(WindowsQuery().Into(&snapshot.Windows)).
+		Query(ActiveWorkspaceQuery().Into(&snapshot.ActiveWorkspace)).
+		Query(ActiveWindowQuery().Into(activeWindow)).
+		Query(LayersQuery().Into(&snapshot.Layers)).
+		Query(DevicesQuery().Into(&snapshot.Devices)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Hyprland replies with an empty object when no window has focus.
+	if activeWindow.Address != "" {
+		snapshot.ActiveWindow = activeWindow
+	}
+
+	return snapshot, nil
+}
+
+// Monitor returns the monitor with the given name.
+func (s *Snapshot) Monitor(name string) (Monitor, bool) {
+	for _, monitor := range s.Monitors {
+		if monitor.Name == name {
+			return monitor, true
+		}
+	}
+
+	return Monitor{}, false
+}
+
+// Workspace returns the workspace with the given ID.
+func (s *Snapshot) Workspace(id int) (Workspace, bool) {
+	for _, workspace := range s.Workspaces {
+		if workspace.Id == id {
+			return workspace, true
+		}
+	}
+
+	return Workspace{}, false
+}
+
+// Window returns the window with the given address, with or without the "0x"
+// prefix.
+func (s *Snapshot) Window(address string) (Window, bool) {
+	address = strings.TrimPrefix(address, "0x")
+	for _, window := range s.Windows {
+		if strings.TrimPrefix(window.Address, "0x") == address {
+			return window, true
+		}
+	}
+
+	return Window{}, false
+}
+
+// WorkspacesOn returns the workspaces on the monitor with the given name.
+func (s *Snapshot) WorkspacesOn(monitor string) []Workspace {
+	workspaces := make([]Workspace, 0)
+	for _, workspace := range s.Workspaces {
+		if workspace.Monitor == monitor {
+			workspaces = append(workspaces, workspace)
+		}
+	}
+
+	return workspaces
+}
+
+// WindowsOn returns the windows on the workspace with the given ID.
+func (s *Snapshot) WindowsOn(workspaceID int) []Window {
+	windows := make([]Window, 0)
+	for _, window := range s.Windows {
+		if window.Workspace.Id == workspaceID {
+			windows = append(windows, window)
+		}
+	}
+
+	return windows
+}
+
+// WorkspaceOf returns the workspace a window is on.
+func (s *Snapshot) WorkspaceOf(window Window) (Workspace, bool) {
+	return s.Workspace(window.Workspace.Id)
+}
+
+// MonitorOf returns the monitor a workspace is on.
+func (s *Snapshot) MonitorOf(workspace Workspace) (Monitor, bool) {
+	return s.Monitor(workspace.Monitor)
+}
@@ -0,0 +1,54 @@
+package hypr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetSnapshot(t *testing.T) {
+	replies := []string{
+		`[{"id":0,"name":"DP-1"}]`,
+		`[{"id":1,"name":"1","monitor":"DP-1"},{"id":2,"name":"2","monitor":"DP-2"}]`,
+		`[{"address":"0x1","workspace":{"id":1,"name":"1"}},{"address":"0x2","workspace":{"id":2,"name":"2"}}]`,
+		`{"id":1,"name":"1","monitor":"DP-1"}`,
+		`{}`,
+		`{"DP-1":{"levels":{"0":[{"address":"0x3","namespace":"wallpaper"}],"2":[]}}}`,
+		`{"mice":[],"keyboards":[{"name":"keyboard","main":true}]}`,
+	}
+
+	c, err := NewClient(WithSocketPath(serveOnce(t, strings.Join(replies, "\n\n\n"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := c.GetSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if snapshot.ActiveWindow != nil {
+		t.Errorf("expected no active window, got %+v", snapshot.ActiveWindow)
+	}
+
+	if windows := snapshot.WindowsOn(snapshot.ActiveWorkspace.Id); len(windows) != 1 || windows[0].Address != "0x1" {
+		t.Errorf("expected window 0x1 on the active workspace, got %v", windows)
+	}
+
+	if monitor, ok := snapshot.MonitorOf(snapshot.ActiveWorkspace); !ok || monitor.Name != "DP-1" {
+		t.Errorf("expected DP-1 for the active workspace, got %v", monitor)
+	}
+
+	if window, ok := snapshot.Window("2"); !ok {
+		t.Errorf("expected to find window 2, got %v", window)
+	} else if workspace, ok := snapshot.WorkspaceOf(window); !ok || workspace.Monitor != "DP-2" {
+		t.Errorf("expected workspace 2 on DP-2, got %v", workspace)
+	}
+
+	if layers := snapshot.Layers["DP-1"].Levels[LayerBackground]; len(layers) != 1 || layers[0].Namespace != "wallpaper" {
+		t.Errorf("expected the wallpaper layer, got %v", layers)
+	}
+
+	if len(snapshot.Devices.Keyboards) != 1 || !snapshot.Devices.Keyboards[0].Main {
+		t.Errorf("expected the main keyboard, got %v", snapshot.Devices.Keyboards)
+	}
+}
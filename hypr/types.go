@@ -111,3 +111,48 @@ type Touch struct {
 type Switch struct {
 	HID
 }
+
+// LayerTable holds the layer surfaces of every monitor, keyed by monitor name.
+type LayerTable map[string]MonitorLayers
+
+type MonitorLayers struct {
+	Levels map[LayerLevel][]Layer `json:"levels"`
+}
+
+// LayerLevel is the stacking level of a layer surface.
+type LayerLevel int
+
+const (
+	LayerBackground LayerLevel = 0
+	LayerBottom     LayerLevel = 1
+	LayerTop        LayerLevel = 2
+	LayerOverlay    LayerLevel = 3
+)
+
+type Layer struct {
+	Address   string `json:"address"`
+	X         int    `json:"x"`
+	Y         int    `json:"y"`
+	Width     int    `json:"w"`
+	Height    int    `json:"h"`
+	Namespace string `json:"namespace"`
+	Pid       int    `json:"pid"`
+}
+
+type Bind struct {
+	Locked         bool      `json:"locked"`
+	Mouse          bool      `json:"mouse"`
+	Release        bool      `json:"release"`
+	Repeat         bool      `json:"repeat"`
+	LongPress      bool      `json:"longPress"`
+	NonConsuming   bool      `json:"non_consuming"`
+	HasDescription bool      `json:"has_description"`
+	Modifiers      Modifiers `json:"modmask"`
+	Submap         string    `json:"submap"`
+	Key            string    `json:"key"`
+	Keycode        int       `json:"keycode"`
+	CatchAll       bool      `json:"catch_all"`
+	Description    string    `json:"description"`
+	Dispatcher     string    `json:"dispatcher"`
+	Arg            string    `json:"arg"`
+}